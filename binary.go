@@ -0,0 +1,423 @@
+// Copyright 2025 The Nanoninja Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+package render
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/textproto"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// sniffLen is the number of bytes used to detect the content type.
+const sniffLen = 512
+
+// copyBufferSize is the size of the chunks copied between context checks.
+const copyBufferSize = 32 << 10
+
+// binaryRenderer implements rendering of raw binary content from readers
+// and byte slices, with content type detection and HTTP range support.
+type binaryRenderer struct{}
+
+// Binary creates a renderer copying raw content to the writer.
+// It accepts the following data types:
+// - []byte: written as-is
+// - io.Reader, including fs.File and *os.File: streamed to the writer
+//
+// The content type is detected with http.DetectContentType and can be
+// replaced by options such as MimePDF(). Readers that cannot seek are
+// sniffed from their first read only, so that slow or interactive streams
+// do not block until 512 bytes are available. The Content-Length header is
+// set whenever the remaining size is known, which requires a seekable
+// reader or a reader with a Len method.
+//
+// Example:
+//
+//	f, _ := os.Open("report.pdf")
+//	defer f.Close()
+//	render.Binary().Render(w, f, render.RangeRequest(r), render.WriteResponse(w))
+func Binary() Renderer {
+	return &binaryRenderer{}
+}
+
+// Render writes binary content using a background context.
+// See RenderContext for details on the rendering process.
+func (r *binaryRenderer) Render(w io.Writer, data any, opts ...func(*Options)) error {
+	return r.RenderContext(context.Background(), w, data, opts...)
+}
+
+// RenderContext writes binary content with context support.
+// The context is checked between each copied chunk so that long transfers
+// can be cancelled.
+//
+// When a range was requested with RangeRequest and the data is seekable,
+// only the requested byte ranges are written, as a multipart/byteranges
+// body when several ranges are requested. The range headers are added to
+// the options and, when w is an http.ResponseWriter, written to the
+// response along with the 206 or 416 status code.
+func (r *binaryRenderer) RenderContext(ctx context.Context, w io.Writer, data any, opts ...func(*Options)) error {
+	if err := CheckContext(ctx); err != nil {
+		return err
+	}
+	content, err := newBinaryContent(data)
+	if err != nil {
+		return err
+	}
+	options := NewOptions().
+		Use(content.defaults()...).
+		Use(ContextDefaults(ctx)).
+		Use(opts...)
+
+	if err := options.Err(); err != nil {
+		return err
+	}
+
+	if content.seeker != nil && content.size >= 0 {
+		if header := RangeKey.Get(options); header != "" && ifRangeMatches(options) {
+			return content.writeRanges(ctx, w, header, options)
+		}
+	}
+	return copyContext(ctx, w, content.reader)
+}
+
+// binaryContent is a reader with the metadata needed to serve it.
+type binaryContent struct {
+	reader      io.Reader
+	seeker      io.ReadSeeker // Set when the content supports range requests
+	size        int64         // Remaining size, or -1 when unknown
+	contentType string        // Detected content type, if sniffed
+}
+
+// newBinaryContent inspects data and detects its size and content type.
+func newBinaryContent(data any) (*binaryContent, error) {
+	c := &binaryContent{size: -1}
+
+	switch v := data.(type) {
+	case []byte:
+		c.reader = bytes.NewReader(v)
+	case io.Reader:
+		c.reader = v
+	default:
+		return nil, ErrInvalidData
+	}
+	if s, ok := c.reader.(io.ReadSeeker); ok {
+		c.seeker = s
+	}
+	if err := c.detectSize(); err != nil {
+		return nil, err
+	}
+	return c, c.sniff()
+}
+
+// detectSize determines the remaining size of the content when possible,
+// from the current offset of seekable readers. The size of other readers
+// is unknown since they may already have been partially read.
+func (c *binaryContent) detectSize() error {
+	if v, ok := c.reader.(interface{ Len() int }); ok {
+		c.size = int64(v.Len())
+		return nil
+	}
+	if c.seeker == nil {
+		return nil
+	}
+	current, err := c.seeker.Seek(0, io.SeekCurrent)
+	if err != nil {
+		// Not actually seekable, such as a pipe behind *os.File
+		c.seeker = nil
+		return nil
+	}
+	end, err := c.seeker.Seek(0, io.SeekEnd)
+	if err != nil {
+		return err
+	}
+	if _, err := c.seeker.Seek(current, io.SeekStart); err != nil {
+		return err
+	}
+	c.size = end - current
+	return nil
+}
+
+// sniff detects the content type from the first bytes of the content
+// without consuming them. Seekable content is read up to sniffLen bytes,
+// while other readers are only read once since they may block.
+func (c *binaryContent) sniff() error {
+	var (
+		buf = make([]byte, sniffLen)
+		n   int
+		err error
+	)
+	if c.seeker != nil {
+		n, err = io.ReadFull(c.reader, buf)
+	} else {
+		n, err = c.reader.Read(buf)
+	}
+	if err != nil && err != io.EOF && err != io.ErrUnexpectedEOF {
+		return err
+	}
+	c.contentType = http.DetectContentType(buf[:n])
+
+	if c.seeker != nil {
+		_, err = c.seeker.Seek(int64(-n), io.SeekCurrent)
+		return err
+	}
+	c.reader = io.MultiReader(bytes.NewReader(buf[:n]), c.reader)
+	return nil
+}
+
+// defaults returns the options describing the content.
+func (c *binaryContent) defaults() []func(*Options) {
+	return []func(*Options){
+		Header(func(h HeaderOptions) {
+			if c.contentType != "" {
+				h.Set("Content-Type", c.contentType)
+			}
+			if c.size >= 0 {
+				h.Set("Content-Length", strconv.FormatInt(c.size, 10))
+			}
+			if c.seeker != nil && c.size >= 0 {
+				h.Set("Accept-Ranges", "bytes")
+			}
+		}),
+	}
+}
+
+// writeRanges writes the requested byte ranges of a seekable content.
+func (c *binaryContent) writeRanges(ctx context.Context, w io.Writer, header string, o *Options) error {
+	ranges, err := parseRange(header, c.size)
+	if err != nil {
+		if errors.Is(err, ErrRangeNotSatisfiable) {
+			o.header.Del("Content-Length")
+			o.header.Set("Content-Range", "bytes */"+strconv.FormatInt(c.size, 10))
+			writeStatus(w, o, http.StatusRequestedRangeNotSatisfiable, "Content-Range", "Content-Length")
+			return err
+		}
+		// Invalid range headers are ignored and the full content is sent
+		return copyContext(ctx, w, c.reader)
+	}
+	if ranges == nil {
+		return copyContext(ctx, w, c.reader)
+	}
+	start, err := c.seeker.Seek(0, io.SeekCurrent)
+	if err != nil {
+		return err
+	}
+	if len(ranges) == 1 {
+		ra := ranges[0]
+		o.header.Set("Content-Range", ra.contentRange(c.size))
+		o.header.Set("Content-Length", strconv.FormatInt(ra.length, 10))
+		writeStatus(w, o, http.StatusPartialContent, "Content-Range", "Content-Length")
+
+		if _, err := c.seeker.Seek(start+ra.start, io.SeekStart); err != nil {
+			return err
+		}
+		return copyContext(ctx, w, io.LimitReader(c.seeker, ra.length))
+	}
+	contentType := o.ContentType()
+	mw := multipart.NewWriter(w)
+	length := multipartRangesSize(ranges, mw.Boundary(), contentType, c.size)
+
+	o.header.Set("Content-Type", "multipart/byteranges; boundary="+mw.Boundary())
+	o.header.Set("Content-Length", strconv.FormatInt(length, 10))
+	writeStatus(w, o, http.StatusPartialContent, "Content-Type", "Content-Length")
+
+	for _, ra := range ranges {
+		part, err := mw.CreatePart(ra.partHeader(contentType, c.size))
+		if err != nil {
+			return err
+		}
+		if _, err := c.seeker.Seek(start+ra.start, io.SeekStart); err != nil {
+			return err
+		}
+		if err := copyContext(ctx, part, io.LimitReader(c.seeker, ra.length)); err != nil {
+			return err
+		}
+	}
+	return mw.Close()
+}
+
+// byteRange is a single satisfiable range of a content.
+type byteRange struct {
+	start, length int64
+}
+
+func (r byteRange) contentRange(size int64) string {
+	return fmt.Sprintf("bytes %d-%d/%d", r.start, r.start+r.length-1, size)
+}
+
+func (r byteRange) partHeader(contentType string, size int64) textproto.MIMEHeader {
+	return textproto.MIMEHeader{
+		"Content-Range": {r.contentRange(size)},
+		"Content-Type":  {contentType},
+	}
+}
+
+// parseRange parses an HTTP Range header value as defined by RFC 7233.
+// It returns nil ranges when the full content should be served instead,
+// ErrInvalidParam for malformed values and ErrRangeNotSatisfiable when
+// none of the ranges overlap the content.
+func parseRange(header string, size int64) ([]byteRange, error) {
+	const prefix = "bytes="
+	if !strings.HasPrefix(header, prefix) {
+		return nil, ErrInvalidParam
+	}
+	var ranges []byteRange
+	var total int64
+	satisfiable := false
+
+	for _, spec := range strings.Split(header[len(prefix):], ",") {
+		spec = strings.TrimSpace(spec)
+		if spec == "" {
+			continue
+		}
+		first, last, ok := strings.Cut(spec, "-")
+		if !ok {
+			return nil, ErrInvalidParam
+		}
+		first, last = strings.TrimSpace(first), strings.TrimSpace(last)
+
+		var ra byteRange
+		if first == "" {
+			// Suffix range: the last n bytes
+			n, err := strconv.ParseInt(last, 10, 64)
+			if err != nil || n < 0 {
+				return nil, ErrInvalidParam
+			}
+			if n == 0 {
+				continue
+			}
+			if n > size {
+				n = size
+			}
+			ra = byteRange{start: size - n, length: n}
+		} else {
+			start, err := strconv.ParseInt(first, 10, 64)
+			if err != nil || start < 0 {
+				return nil, ErrInvalidParam
+			}
+			end := size - 1
+			if last != "" {
+				end, err = strconv.ParseInt(last, 10, 64)
+				if err != nil || end < start {
+					return nil, ErrInvalidParam
+				}
+			}
+			if start >= size {
+				continue
+			}
+			if end >= size {
+				end = size - 1
+			}
+			ra = byteRange{start: start, length: end - start + 1}
+		}
+		satisfiable = satisfiable || ra.length > 0
+		total += ra.length
+		ranges = append(ranges, ra)
+	}
+	if !satisfiable {
+		return nil, ErrRangeNotSatisfiable
+	}
+	if total > size {
+		// Overlapping ranges requesting more than the content itself
+		return nil, nil
+	}
+	return ranges, nil
+}
+
+// multipartRangesSize computes the length of a multipart/byteranges body.
+func multipartRangesSize(ranges []byteRange, boundary, contentType string, size int64) int64 {
+	cw := &countWriter{w: io.Discard}
+	mw := multipart.NewWriter(cw)
+	_ = mw.SetBoundary(boundary)
+
+	var length int64
+	for _, ra := range ranges {
+		_, _ = mw.CreatePart(ra.partHeader(contentType, size))
+		length += ra.length
+	}
+	_ = mw.Close()
+	return cw.n + length
+}
+
+// ifRangeMatches reports whether a range request may be honored according
+// to the If-Range precondition and the validators set in the headers.
+func ifRangeMatches(o *Options) bool {
+	ifRange := IfRangeKey.Get(o)
+	if ifRange == "" {
+		return true
+	}
+	if strings.HasPrefix(ifRange, `"`) || strings.HasPrefix(ifRange, "W/") {
+		// Only strong entity tags can validate a range request
+		etag := o.header.Get("Etag")
+		return etag != "" && !strings.HasPrefix(etag, "W/") && etag == ifRange
+	}
+	modified, err := http.ParseTime(o.header.Get("Last-Modified"))
+	if err != nil {
+		return false
+	}
+	since, err := http.ParseTime(ifRange)
+	if err != nil {
+		return false
+	}
+	return modified.Truncate(time.Second).Equal(since)
+}
+
+// writeStatus writes the given headers and status code when w is an
+// http.ResponseWriter.
+func writeStatus(w io.Writer, o *Options, status int, keys ...string) {
+	rw, ok := w.(http.ResponseWriter)
+	if !ok {
+		return
+	}
+	for _, key := range keys {
+		if value := o.header.Get(key); value != "" {
+			rw.Header().Set(key, value)
+		} else {
+			rw.Header().Del(key)
+		}
+	}
+	rw.WriteHeader(status)
+}
+
+// copyContext copies src to dst, checking the context between chunks.
+func copyContext(ctx context.Context, dst io.Writer, src io.Reader) error {
+	buf := make([]byte, copyBufferSize)
+	for {
+		if err := CheckContext(ctx); err != nil {
+			return err
+		}
+		n, err := src.Read(buf)
+		if n > 0 {
+			if _, werr := dst.Write(buf[:n]); werr != nil {
+				return werr
+			}
+		}
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// RangeRequest returns an option function forwarding the Range and If-Range
+// headers of an HTTP request to renderers supporting partial content,
+// such as Binary.
+//
+// Example:
+//
+//	render.Binary().Render(w, file, render.RangeRequest(r), render.WriteResponse(w))
+func RangeRequest(r *http.Request) func(*Options) {
+	return With(
+		Set(RangeKey, r.Header.Get("Range")),
+		Set(IfRangeKey, r.Header.Get("If-Range")),
+	)
+}
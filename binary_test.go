@@ -0,0 +1,347 @@
+// Copyright 2025 The Nanoninja Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+package render
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"io/fs"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"github.com/nanoninja/assert"
+)
+
+var (
+	_ Renderer = (*binaryRenderer)(nil)
+	_ Renderer = Binary()
+)
+
+// rangeOption simulates a request carrying the given range headers.
+func rangeOption(rangeHeader, ifRange string) func(*Options) {
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+	r.Header.Set("Range", rangeHeader)
+	if ifRange != "" {
+		r.Header.Set("If-Range", ifRange)
+	}
+	return RangeRequest(r)
+}
+
+func TestBinaryRenderer(t *testing.T) {
+	t.Run("RendersByteSlice", func(t *testing.T) {
+		var w bytes.Buffer
+		var opts *Options
+
+		err := Binary().Render(&w, []byte("%PDF-1.7 content"), CaptureOptions(&opts))
+
+		assert.Nil(t, err)
+		assert.Equals(t, w.String(), "%PDF-1.7 content")
+		assert.Equals(t, opts.ContentType(), "application/pdf")
+		assert.Equals(t, opts.Header().Get("Content-Length"), "16")
+		assert.Equals(t, opts.Header().Get("Accept-Ranges"), "bytes")
+	})
+
+	t.Run("StreamsReaderOfUnknownSize", func(t *testing.T) {
+		var w bytes.Buffer
+		var opts *Options
+
+		content := strings.Repeat("plain text ", 100)
+		reader := io.MultiReader(strings.NewReader(content))
+
+		err := Binary().Render(&w, reader, CaptureOptions(&opts))
+
+		assert.Nil(t, err)
+		assert.Equals(t, w.String(), content)
+		assert.Equals(t, opts.ContentType(), "text/plain; charset=utf-8")
+		assert.Equals(t, opts.Header().Get("Content-Length"), "")
+		assert.Equals(t, opts.Header().Get("Accept-Ranges"), "")
+	})
+
+	t.Run("RendersFSFile", func(t *testing.T) {
+		var w bytes.Buffer
+		var opts *Options
+
+		fsys := fstest.MapFS{"logo.png": {Data: []byte("\x89PNG\x0D\x0A\x1A\x0Adata")}}
+		f, err := fsys.Open("logo.png")
+		assert.Nil(t, err)
+		defer f.Close()
+
+		err = Binary().Render(&w, f, CaptureOptions(&opts))
+
+		assert.Nil(t, err)
+		assert.Equals(t, w.Len(), 12)
+		assert.Equals(t, opts.ContentType(), "image/png")
+		assert.Equals(t, opts.Header().Get("Content-Length"), "12")
+	})
+
+	t.Run("RendersOSFileFromCurrentOffset", func(t *testing.T) {
+		var w bytes.Buffer
+		var opts *Options
+
+		path := filepath.Join(t.TempDir(), "data.bin")
+		assert.Nil(t, os.WriteFile(path, []byte("0123456789"), 0o600))
+
+		f, err := os.Open(path)
+		assert.Nil(t, err)
+		defer f.Close()
+
+		_, err = f.Seek(4, io.SeekStart)
+		assert.Nil(t, err)
+
+		err = Binary().Render(&w, f, CaptureOptions(&opts))
+
+		assert.Nil(t, err)
+		assert.Equals(t, w.String(), "456789")
+		assert.Equals(t, opts.Header().Get("Content-Length"), "6")
+	})
+
+	t.Run("KeepsExplicitContentType", func(t *testing.T) {
+		var opts *Options
+
+		err := Binary().Render(io.Discard, []byte("data"), MimePDF(), CaptureOptions(&opts))
+
+		assert.Nil(t, err)
+		assert.Equals(t, opts.ContentType(), "application/pdf")
+	})
+
+	t.Run("SniffsFirstReadOfStreams", func(t *testing.T) {
+		var w bytes.Buffer
+		var opts *Options
+		pr, pw := io.Pipe()
+
+		go func() {
+			_, _ = io.WriteString(pw, "%PDF-1.7")
+			_, _ = io.WriteString(pw, " rest")
+			_ = pw.Close()
+		}()
+		reader := &readSizeRecorder{r: pr}
+
+		err := Binary().Render(&w, reader, CaptureOptions(&opts))
+
+		assert.Nil(t, err)
+		assert.Equals(t, w.String(), "%PDF-1.7 rest")
+		assert.Equals(t, opts.ContentType(), "application/pdf")
+		assert.Equals(t, reader.sizes[0], sniffLen)
+	})
+
+	t.Run("ExplicitContentTypeReplacesSniffedType", func(t *testing.T) {
+		var opts *Options
+
+		err := Binary().Render(io.Discard, []byte("%PDF-1.7"), MimeTextPlain(), CaptureOptions(&opts))
+
+		assert.Nil(t, err)
+		assert.Equals(t, opts.ContentType(), "text/plain; charset=utf-8")
+	})
+
+	t.Run("OmitsLengthOfPartiallyReadFiles", func(t *testing.T) {
+		var w bytes.Buffer
+		var opts *Options
+
+		fsys := fstest.MapFS{"data.txt": {Data: []byte("0123456789")}}
+		f, err := fsys.Open("data.txt")
+		assert.Nil(t, err)
+		defer f.Close()
+
+		_, err = f.Read(make([]byte, 4))
+		assert.Nil(t, err)
+
+		// Hide the Seek method of the file
+		err = Binary().Render(&w, struct{ fs.File }{f}, CaptureOptions(&opts))
+
+		assert.Nil(t, err)
+		assert.Equals(t, w.String(), "456789")
+		assert.Equals(t, opts.Header().Get("Content-Length"), "")
+	})
+
+	t.Run("ReturnsErrorForInvalidDataType", func(t *testing.T) {
+		err := Binary().Render(io.Discard, 42)
+
+		assert.ErrorIs(t, err, ErrInvalidData)
+	})
+
+	t.Run("RespectsContextCancellation", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		err := Binary().RenderContext(ctx, io.Discard, []byte("data"))
+
+		assert.ErrorIs(t, err, context.Canceled)
+	})
+
+	t.Run("StopsCopyingWhenContextIsDone", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+
+		w := &checkWriterTest{onWrite: func(p []byte) (int, error) {
+			cancel()
+			return len(p), nil
+		}}
+		content := bytes.Repeat([]byte("x"), 3*copyBufferSize)
+
+		err := Binary().RenderContext(ctx, w, io.MultiReader(bytes.NewReader(content)))
+
+		assert.ErrorIs(t, err, context.Canceled)
+	})
+}
+
+func TestBinaryRenderer_Range(t *testing.T) {
+	content := []byte("0123456789abcdefghij")
+
+	t.Run("WritesSingleRange", func(t *testing.T) {
+		var opts *Options
+
+		rec := httptest.NewRecorder()
+		err := Binary().Render(rec, content, rangeOption("bytes=2-5", ""), CaptureOptions(&opts))
+
+		assert.Nil(t, err)
+		assert.Equals(t, rec.Code, http.StatusPartialContent)
+		assert.Equals(t, rec.Body.String(), "2345")
+		assert.Equals(t, rec.Header().Get("Content-Range"), "bytes 2-5/20")
+		assert.Equals(t, rec.Header().Get("Content-Length"), "4")
+	})
+
+	t.Run("WritesSuffixAndOpenRanges", func(t *testing.T) {
+		var w bytes.Buffer
+
+		assert.Nil(t, Binary().Render(&w, content, rangeOption("bytes=-3", "")))
+		assert.Equals(t, w.String(), "hij")
+
+		w.Reset()
+		assert.Nil(t, Binary().Render(&w, content, rangeOption("bytes=17-", "")))
+		assert.Equals(t, w.String(), "hij")
+	})
+
+	t.Run("WritesMultipartByteRanges", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+
+		err := Binary().Render(rec, content, rangeOption("bytes=0-1, 10-12", ""))
+		assert.Nil(t, err)
+		assert.Equals(t, rec.Code, http.StatusPartialContent)
+
+		mediatype, params, err := mime.ParseMediaType(rec.Header().Get("Content-Type"))
+		assert.Nil(t, err)
+		assert.Equals(t, mediatype, "multipart/byteranges")
+		assert.Equals(t, rec.Header().Get("Content-Length"), strconv.Itoa(rec.Body.Len()))
+
+		mr := multipart.NewReader(rec.Body, params["boundary"])
+		var parts []string
+		for {
+			part, err := mr.NextPart()
+			if err == io.EOF {
+				break
+			}
+			assert.Nil(t, err)
+
+			body, err := io.ReadAll(part)
+			assert.Nil(t, err)
+			parts = append(parts, part.Header.Get("Content-Range")+"="+string(body))
+		}
+		assert.Equals(t, strings.Join(parts, ";"), "bytes 0-1/20=01;bytes 10-12/20=abc")
+	})
+
+	t.Run("RejectsUnsatisfiableRange", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+
+		err := Binary().Render(rec, content, rangeOption("bytes=50-60", ""))
+
+		assert.ErrorIs(t, err, ErrRangeNotSatisfiable)
+		assert.Equals(t, rec.Code, http.StatusRequestedRangeNotSatisfiable)
+		assert.Equals(t, rec.Header().Get("Content-Range"), "bytes */20")
+		assert.Equals(t, rec.Body.Len(), 0)
+	})
+
+	t.Run("IgnoresMalformedRange", func(t *testing.T) {
+		var w bytes.Buffer
+
+		err := Binary().Render(&w, content, rangeOption("items=0-1", ""))
+
+		assert.Nil(t, err)
+		assert.Equals(t, w.String(), string(content))
+	})
+
+	t.Run("IgnoresRangeForNonSeekableReader", func(t *testing.T) {
+		var w bytes.Buffer
+
+		err := Binary().Render(&w, io.MultiReader(bytes.NewReader(content)), rangeOption("bytes=0-1", ""))
+
+		assert.Nil(t, err)
+		assert.Equals(t, w.String(), string(content))
+	})
+
+	t.Run("HonorsMatchingIfRangeETag", func(t *testing.T) {
+		var w bytes.Buffer
+
+		etag := Header(func(h HeaderOptions) { h.Set("ETag", `"v1"`) })
+		err := Binary().Render(&w, content, etag, rangeOption("bytes=0-1", `"v1"`))
+
+		assert.Nil(t, err)
+		assert.Equals(t, w.String(), "01")
+	})
+
+	t.Run("IgnoresRangeForStaleIfRange", func(t *testing.T) {
+		var w bytes.Buffer
+
+		etag := Header(func(h HeaderOptions) { h.Set("ETag", `"v2"`) })
+		err := Binary().Render(&w, content, etag, rangeOption("bytes=0-1", `"v1"`))
+
+		assert.Nil(t, err)
+		assert.Equals(t, w.String(), string(content))
+	})
+
+	t.Run("HonorsMatchingIfRangeDate", func(t *testing.T) {
+		var w bytes.Buffer
+
+		date := "Wed, 21 Oct 2015 07:28:00 GMT"
+		modified := Header(func(h HeaderOptions) { h.Set("Last-Modified", date) })
+		err := Binary().Render(&w, content, modified, rangeOption("bytes=0-1", date))
+
+		assert.Nil(t, err)
+		assert.Equals(t, w.String(), "01")
+	})
+}
+
+func TestParseRange(t *testing.T) {
+	tests := []struct {
+		header   string
+		expected []byteRange
+		err      error
+	}{
+		{header: "bytes=0-0", expected: []byteRange{{0, 1}}},
+		{header: "bytes=5-100", expected: []byteRange{{5, 5}}},
+		{header: "bytes=-100", expected: []byteRange{{0, 10}}},
+		{header: "bytes=0-1,3-4", expected: []byteRange{{0, 2}, {3, 2}}},
+		{header: "bytes=0-9,0-9", expected: nil},
+		{header: "bytes=10-", err: ErrRangeNotSatisfiable},
+		{header: "bytes=4-2", err: ErrInvalidParam},
+		{header: "bytes=a-b", err: ErrInvalidParam},
+		{header: "bytes 0-1", err: ErrInvalidParam},
+	}
+	for _, tt := range tests {
+		t.Run(tt.header, func(t *testing.T) {
+			ranges, err := parseRange(tt.header, 10)
+
+			assert.ErrorIs(t, err, tt.err)
+			assert.Equals(t, ranges, tt.expected)
+		})
+	}
+}
+
+// readSizeRecorder records the size of the buffers passed to Read.
+type readSizeRecorder struct {
+	r     io.Reader
+	sizes []int
+}
+
+func (r *readSizeRecorder) Read(p []byte) (int, error) {
+	r.sizes = append(r.sizes, len(p))
+	return r.r.Read(p)
+}
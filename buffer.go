@@ -8,8 +8,38 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"sync"
 )
 
+// maxPooledBufferSize is the largest buffer capacity returned to the pool.
+// Larger buffers are left to the garbage collector so that a single huge
+// render does not pin its memory for the lifetime of the process.
+const maxPooledBufferSize = 64 << 10
+
+// bufferPool recycles rendering buffers across BufferRenderer calls.
+var bufferPool = sync.Pool{
+	New: func() any { return new(bytes.Buffer) },
+}
+
+// getBuffer returns an empty buffer from the pool.
+func getBuffer() *bytes.Buffer {
+	return bufferPool.Get().(*bytes.Buffer)
+}
+
+// putBuffer resets buf and returns it to the pool if it is small enough.
+func putBuffer(buf *bytes.Buffer) {
+	if !poolable(buf) {
+		return
+	}
+	buf.Reset()
+	bufferPool.Put(buf)
+}
+
+// poolable reports whether buf is small enough to be returned to the pool.
+func poolable(buf *bytes.Buffer) bool {
+	return buf.Cap() <= maxPooledBufferSize
+}
+
 // BufferConfig holds configuration for the BufferRenderer.
 // It allows customization of initial buffer size and post-processing functionality.
 type BufferConfig struct {
@@ -19,6 +49,13 @@ type BufferConfig struct {
 	// If 0, the default buffer size is used.
 	InitialSize int
 
+	// MaxSize limits the size in bytes of the rendered content.
+	// Rendering is aborted with a *MaxSizeError as soon as the limit is
+	// exceeded, which protects against runaway templates or unexpectedly
+	// large data sets. The limit also applies to the post-processed content.
+	// If 0, the size is not limited.
+	MaxSize int
+
 	// PostRender defines a function to transform or validate the rendered content
 	// before it is written to the output. This can be used for tasks like:
 	// - Content minification
@@ -27,6 +64,12 @@ type BufferConfig struct {
 	// - Content transformation
 	// - Metadata injection
 	// If nil, the content is written as-is.
+	//
+	// The content slice refers to a pooled buffer and must not be retained
+	// after the function returns.
+	//
+	// The same function can be used as a streaming stage of a Pipeline
+	// through PostRenderFunc.
 	PostRender func([]byte) ([]byte, error)
 }
 
@@ -38,6 +81,7 @@ type BufferConfig struct {
 type BufferRenderer struct {
 	renderer    Renderer                     // The underlying renderer to buffer
 	initialSize int                          // Initial buffer size if specified
+	maxSize     int                          // Maximum content size, 0 for unlimited
 	postRender  func([]byte) ([]byte, error) // Optional post-processing function
 }
 
@@ -71,6 +115,7 @@ func NewBuffer(r Renderer, c BufferConfig) *BufferRenderer {
 	return &BufferRenderer{
 		renderer:    r,
 		initialSize: c.InitialSize,
+		maxSize:     c.MaxSize,
 		postRender:  c.PostRender,
 	}
 }
@@ -83,8 +128,8 @@ func (r *BufferRenderer) Render(w io.Writer, data any, opts ...func(*Options)) e
 
 // RenderContext implements buffered rendering with context support.
 // The rendering process follows these steps:
-// 1. Creates a buffer (pre-allocated if InitialSize > 0)
-// 2. Renders content to the buffer using the wrapped renderer
+// 1. Takes a buffer from the pool (pre-allocated if InitialSize > 0)
+// 2. Renders content to the buffer, aborting once MaxSize is exceeded
 // 3. If configured, applies post-processing to the buffered content
 // 4. Writes the final content to the provided writer
 //
@@ -94,13 +139,19 @@ func (r *BufferRenderer) RenderContext(ctx context.Context, w io.Writer, data an
 	if err := CheckContext(ctx); err != nil {
 		return err
 	}
-	// Create a buffer with initial size if configured
-	var buf bytes.Buffer
+	// Reuse a pooled buffer, growing it to the initial size if configured
+	buf := getBuffer()
+	defer putBuffer(buf)
+
 	if r.initialSize > 0 {
 		buf.Grow(r.initialSize)
 	}
-	// Render to buffer
-	if err := r.renderer.RenderContext(ctx, &buf, data, opts...); err != nil {
+	// Render to buffer, enforcing the size limit on every write
+	var dst io.Writer = buf
+	if r.maxSize > 0 {
+		dst = &limitWriter{w: buf, limit: r.maxSize}
+	}
+	if err := r.renderer.RenderContext(ctx, dst, data, opts...); err != nil {
 		return fmt.Errorf("buffer render: %w", err)
 	}
 	// Get buffered content
@@ -116,6 +167,10 @@ func (r *BufferRenderer) RenderContext(ctx context.Context, w io.Writer, data an
 			return fmt.Errorf("post-processing: %w", err)
 		}
 		content = transformed
+
+		if r.maxSize > 0 && len(content) > r.maxSize {
+			return fmt.Errorf("post-processing: %w", &MaxSizeError{Limit: r.maxSize})
+		}
 	}
 	// Check context one last time before writing
 	if err := CheckContext(ctx); err != nil {
@@ -125,3 +180,36 @@ func (r *BufferRenderer) RenderContext(ctx context.Context, w io.Writer, data an
 	_, err := w.Write(content)
 	return err
 }
+
+// MaxSizeError is returned when rendered content exceeds BufferConfig.MaxSize.
+// It matches ErrMaxSizeExceeded with errors.Is.
+type MaxSizeError struct {
+	// Limit is the configured maximum size in bytes.
+	Limit int
+}
+
+// Error implements the error interface.
+func (e *MaxSizeError) Error() string {
+	return fmt.Sprintf("%s: limit is %d bytes", ErrMaxSizeExceeded, e.Limit)
+}
+
+// Unwrap returns ErrMaxSizeExceeded so callers can test for the error kind.
+func (e *MaxSizeError) Unwrap() error {
+	return ErrMaxSizeExceeded
+}
+
+// limitWriter fails writes that would grow the content beyond limit bytes.
+type limitWriter struct {
+	w       io.Writer
+	limit   int
+	written int
+}
+
+func (l *limitWriter) Write(p []byte) (int, error) {
+	if l.written+len(p) > l.limit {
+		return 0, &MaxSizeError{Limit: l.limit}
+	}
+	n, err := l.w.Write(p)
+	l.written += n
+	return n, err
+}
@@ -267,6 +267,89 @@ func TestBufferRenderer_ContextCheckDuringPostProcess(t *testing.T) {
 	assert.ErrorIs(t, err, context.DeadlineExceeded)
 }
 
+func TestBufferRenderer_MaxSize(t *testing.T) {
+	t.Run("RendersContentWithinLimit", func(t *testing.T) {
+		var w bytes.Buffer
+
+		renderer := &mockRenderer{content: "test content"}
+		config := BufferConfig{MaxSize: 12}
+
+		err := NewBuffer(renderer, config).Render(&w, nil)
+
+		assert.Nil(t, err)
+		assert.Equals(t, w.String(), "test content")
+	})
+
+	t.Run("AbortsWhenLimitIsExceeded", func(t *testing.T) {
+		var w bytes.Buffer
+
+		renderer := &mockRenderer{content: "test content"}
+		config := BufferConfig{MaxSize: 4}
+
+		err := NewBuffer(renderer, config).Render(&w, nil)
+
+		var sizeErr *MaxSizeError
+		assert.ErrorIs(t, err, ErrMaxSizeExceeded)
+		assert.ErrorAs(t, err, &sizeErr)
+		assert.Equals(t, sizeErr.Limit, 4)
+		assert.Equals(t, w.String(), "")
+	})
+
+	t.Run("AbortsWhenPostProcessedContentExceedsLimit", func(t *testing.T) {
+		var w bytes.Buffer
+
+		renderer := &mockRenderer{content: "test"}
+		config := BufferConfig{
+			MaxSize: 6,
+			PostRender: func(content []byte) ([]byte, error) {
+				return bytes.Repeat(content, 2), nil
+			},
+		}
+
+		err := NewBuffer(renderer, config).Render(&w, nil)
+
+		assert.ErrorIs(t, err, ErrMaxSizeExceeded)
+		assert.StringContains(t, err.Error(), "post-processing")
+		assert.Equals(t, w.String(), "")
+	})
+}
+
+func TestBufferRenderer_ReusesPooledBuffers(t *testing.T) {
+	renderer := Buffer(&mockRenderer{content: "test content"})
+
+	for i := 0; i < 3; i++ {
+		var w bytes.Buffer
+
+		err := renderer.Render(&w, nil)
+
+		assert.Nil(t, err)
+		assert.Equals(t, w.String(), "test content")
+	}
+}
+
+func TestPutBuffer_DropsOversizedBuffers(t *testing.T) {
+	buf := bytes.NewBuffer(make([]byte, 0, maxPooledBufferSize+1))
+	buf.WriteString("content")
+
+	assert.False(t, poolable(buf))
+	assert.True(t, poolable(bytes.NewBuffer(make([]byte, 0, maxPooledBufferSize))))
+
+	// Dropped buffers are left untouched rather than reset for reuse
+	putBuffer(buf)
+	assert.Equals(t, buf.String(), "content")
+}
+
+func BenchmarkBufferRenderer(b *testing.B) {
+	renderer := NewBuffer(&mockRenderer{content: strings.Repeat("x", 4096)}, BufferConfig{})
+
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		if err := renderer.Render(io.Discard, nil); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
 type mockRenderer struct {
 	content      string
 	err          error
@@ -0,0 +1,346 @@
+// Copyright 2025 The Nanoninja Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+package render
+
+import (
+	"fmt"
+	"io"
+	"mime"
+	"strconv"
+	"strings"
+	"unicode/utf16"
+	"unicode/utf8"
+)
+
+// EncodingPolicy defines how characters that cannot be represented in the
+// declared charset are handled when transcoding.
+type EncodingPolicy int
+
+const (
+	// EncodeError aborts rendering with an error wrapping ErrUnrepresentable.
+	EncodeError EncodingPolicy = iota
+
+	// EncodeReplace replaces the character with a question mark.
+	EncodeReplace
+
+	// EncodeNCR replaces the character with a numeric character reference,
+	// such as &#8364;, for HTML and XML content. Other content types use
+	// EncodeReplace, as references would be written literally.
+	EncodeNCR
+)
+
+// String returns the name of the policy.
+func (p EncodingPolicy) String() string {
+	switch p {
+	case EncodeError:
+		return "error"
+	case EncodeReplace:
+		return "replace"
+	case EncodeNCR:
+		return "ncr"
+	}
+	return "EncodingPolicy(" + strconv.Itoa(int(p)) + ")"
+}
+
+// EncodingPolicyKey holds the policy applied to unrepresentable characters.
+// The default is EncodeError.
+var EncodingPolicyKey = NewKey("encoding.policy", EncodeError)
+
+// OnUnrepresentable returns an option function setting how characters that
+// cannot be represented in the declared charset are handled.
+//
+// Example:
+//
+//	render.CSV().Render(w, records,
+//	    render.Mime("text/csv", "iso-8859-1"),
+//	    render.OnUnrepresentable(render.EncodeReplace),
+//	)
+func OnUnrepresentable(policy EncodingPolicy) func(*Options) {
+	return Set(EncodingPolicyKey, policy)
+}
+
+// charset describes an encoding supported by Transcoder.
+type charset struct {
+	name   string                                  // Canonical name, as used in XML declarations
+	bom    []byte                                  // Byte order mark written first, if any
+	encode func(dst []byte, r rune) ([]byte, bool) // Appends r to dst, or reports it is unrepresentable
+}
+
+// charsets maps lowercase charset labels to their encodings. UTF-8 is
+// handled separately as no transcoding is needed.
+var charsets = map[string]*charset{}
+
+func init() {
+	latin1 := &charset{name: "ISO-8859-1", encode: singleByte(latin1Byte)}
+	latin9 := &charset{name: "ISO-8859-15", encode: singleByte(latin9Byte)}
+	cp1252 := &charset{name: "windows-1252", encode: singleByte(windows1252Byte)}
+	ascii := &charset{name: "US-ASCII", encode: singleByte(asciiByte)}
+	utf16le := &charset{name: "UTF-16LE", bom: []byte{0xFF, 0xFE}, encode: utf16Encoder(false)}
+	utf16be := &charset{name: "UTF-16BE", bom: []byte{0xFE, 0xFF}, encode: utf16Encoder(true)}
+
+	for _, label := range []string{"iso-8859-1", "iso8859-1", "iso_8859-1", "latin1", "l1"} {
+		charsets[label] = latin1
+	}
+	for _, label := range []string{"iso-8859-15", "iso8859-15", "iso_8859-15", "latin-9", "latin9"} {
+		charsets[label] = latin9
+	}
+	for _, label := range []string{"windows-1252", "cp1252", "x-cp1252"} {
+		charsets[label] = cp1252
+	}
+	for _, label := range []string{"us-ascii", "ascii"} {
+		charsets[label] = ascii
+	}
+	charsets["utf-16le"] = utf16le
+	charsets["utf-16be"] = utf16be
+	charsets["utf-16"] = utf16be
+}
+
+// lookupCharset returns the encoding declared by the Content-Type of o.
+// It returns nil when the content is UTF-8 or no charset is declared.
+func lookupCharset(o *Options) (*charset, error) {
+	_, params, err := mime.ParseMediaType(o.ContentType())
+	if err != nil {
+		return nil, nil
+	}
+	label := strings.ToLower(strings.TrimSpace(params["charset"]))
+	switch label {
+	case "", "utf-8", "utf8":
+		return nil, nil
+	}
+	cs, ok := charsets[label]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnsupportedCharset, label)
+	}
+	return cs, nil
+}
+
+// Transcoder returns a writer converting the UTF-8 content written to it
+// into the charset declared by the Content-Type of o, such as
+// "text/csv; charset=iso-8859-1". The writer must be closed to flush a
+// trailing incomplete character; closing it does not close w.
+//
+// Supported charsets are UTF-8, US-ASCII, ISO-8859-1, ISO-8859-15,
+// Windows-1252, UTF-16LE and UTF-16BE. UTF-16 output starts with a byte
+// order mark, and "utf-16" is written as big-endian. An unknown charset
+// returns an error wrapping ErrUnsupportedCharset.
+//
+// Characters that cannot be represented are handled according to the
+// policy set with OnUnrepresentable. Invalid UTF-8 input is treated as
+// the replacement character U+FFFD.
+//
+// Renderers use it once options are resolved:
+//
+//	tw, err := render.Transcoder(w, options)
+//	if err != nil {
+//	    return err
+//	}
+//	// Write UTF-8 content to tw
+//	return tw.Close()
+func Transcoder(w io.Writer, o *Options) (io.WriteCloser, error) {
+	cs, err := lookupCharset(o)
+	if err != nil {
+		return nil, err
+	}
+	if cs == nil {
+		return nopWriteCloser{w}, nil
+	}
+	policy := EncodingPolicyKey.Get(o)
+	if policy == EncodeNCR && !isMarkup(o.ContentType()) {
+		policy = EncodeReplace
+	}
+	return &charsetWriter{
+		w:       w,
+		charset: cs,
+		policy:  policy,
+		bom:     cs.bom != nil,
+	}, nil
+}
+
+// isMarkup reports whether a content type supports numeric character references.
+func isMarkup(contentType string) bool {
+	mediatype, _, _ := mime.ParseMediaType(contentType)
+	return mediatype == "text/html" ||
+		mediatype == "text/xml" ||
+		mediatype == "application/xml" ||
+		strings.HasSuffix(mediatype, "+xml")
+}
+
+// xmlDeclaration returns the XML declaration matching the charset
+// declared by o.
+func xmlDeclaration(o *Options) string {
+	cs, err := lookupCharset(o)
+	if err != nil || cs == nil {
+		return `<?xml version="1.0" encoding="UTF-8"?>` + "\n"
+	}
+	return `<?xml version="1.0" encoding="` + cs.name + `"?>` + "\n"
+}
+
+// nopWriteCloser adds a no-op Close method to a writer.
+type nopWriteCloser struct {
+	io.Writer
+}
+
+func (nopWriteCloser) Close() error { return nil }
+
+// charsetWriter encodes UTF-8 input into another charset.
+type charsetWriter struct {
+	w       io.Writer
+	charset *charset
+	policy  EncodingPolicy
+	bom     bool   // Whether the byte order mark is still to be written
+	pending []byte // Incomplete UTF-8 sequence from the previous write
+	buf     []byte // Encoded output buffer, reused between writes
+}
+
+func (c *charsetWriter) Write(p []byte) (int, error) {
+	data := p
+	if len(c.pending) > 0 {
+		data = append(c.pending, p...)
+		c.pending = nil
+	}
+	c.buf = c.buf[:0]
+	if c.bom {
+		c.buf = append(c.buf, c.charset.bom...)
+		c.bom = false
+	}
+	i := 0
+	for i < len(data) {
+		if !utf8.FullRune(data[i:]) {
+			break
+		}
+		r, size := utf8.DecodeRune(data[i:])
+		if err := c.encode(r); err != nil {
+			return 0, err
+		}
+		i += size
+	}
+	if i < len(data) {
+		c.pending = append([]byte(nil), data[i:]...)
+	}
+	if _, err := c.w.Write(c.buf); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+// Close encodes a trailing incomplete sequence as an invalid character.
+// It does not close the underlying writer.
+func (c *charsetWriter) Close() error {
+	if len(c.pending) == 0 && !c.bom {
+		return nil
+	}
+	c.buf = c.buf[:0]
+	if c.bom {
+		c.buf = append(c.buf, c.charset.bom...)
+		c.bom = false
+	}
+	if len(c.pending) > 0 {
+		c.pending = nil
+		if err := c.encode(utf8.RuneError); err != nil {
+			return err
+		}
+	}
+	_, err := c.w.Write(c.buf)
+	return err
+}
+
+// encode appends r to the output buffer, applying the policy when r
+// cannot be represented.
+func (c *charsetWriter) encode(r rune) error {
+	if buf, ok := c.charset.encode(c.buf, r); ok {
+		c.buf = buf
+		return nil
+	}
+	switch c.policy {
+	case EncodeReplace:
+		c.buf, _ = c.charset.encode(c.buf, '?')
+	case EncodeNCR:
+		for _, b := range "&#" + strconv.Itoa(int(r)) + ";" {
+			c.buf, _ = c.charset.encode(c.buf, b)
+		}
+	default:
+		return fmt.Errorf("%w: %q (U+%04X) in %s", ErrUnrepresentable, r, r, c.charset.name)
+	}
+	return nil
+}
+
+// singleByte builds the encode function of a single-byte charset.
+func singleByte(lookup func(r rune) (byte, bool)) func([]byte, rune) ([]byte, bool) {
+	return func(dst []byte, r rune) ([]byte, bool) {
+		b, ok := lookup(r)
+		if !ok {
+			return dst, false
+		}
+		return append(dst, b), true
+	}
+}
+
+// utf16Encoder builds the encode function of UTF-16 in the given byte order.
+func utf16Encoder(bigEndian bool) func([]byte, rune) ([]byte, bool) {
+	put := func(dst []byte, u uint16) []byte {
+		if bigEndian {
+			return append(dst, byte(u>>8), byte(u))
+		}
+		return append(dst, byte(u), byte(u>>8))
+	}
+	return func(dst []byte, r rune) ([]byte, bool) {
+		if r1, r2 := utf16.EncodeRune(r); r1 != utf8.RuneError {
+			return put(put(dst, uint16(r1)), uint16(r2)), true
+		}
+		return put(dst, uint16(r)), true
+	}
+}
+
+// asciiByte encodes r in US-ASCII.
+func asciiByte(r rune) (byte, bool) {
+	return byte(r), r >= 0 && r < utf8.RuneSelf
+}
+
+// latin1Byte encodes r in ISO-8859-1, whose code points match Unicode.
+func latin1Byte(r rune) (byte, bool) {
+	return byte(r), r >= 0 && r <= 0xFF
+}
+
+// latin9Replaced maps the ISO-8859-15 bytes differing from ISO-8859-1
+// to their characters.
+var latin9Replaced = map[byte]rune{
+	0xA4: '€', 0xA6: 'Š', 0xA8: 'š', 0xB4: 'Ž',
+	0xB8: 'ž', 0xBC: 'Œ', 0xBD: 'œ', 0xBE: 'Ÿ',
+}
+
+// latin9Byte encodes r in ISO-8859-15.
+func latin9Byte(r rune) (byte, bool) {
+	for b, c := range latin9Replaced {
+		if c == r {
+			return b, true
+		}
+	}
+	if _, replaced := latin9Replaced[byte(r)]; replaced && r <= 0xFF {
+		return 0, false
+	}
+	return latin1Byte(r)
+}
+
+// windows1252High lists the characters of the Windows-1252 bytes 0x80 to
+// 0x9F. Zero entries are undefined bytes.
+var windows1252High = [32]rune{
+	'€', 0, '‚', 'ƒ', '„', '…', '†', '‡', 'ˆ', '‰', 'Š', '‹', 'Œ', 0, 'Ž', 0,
+	0, '‘', '’', '“', '”', '•', '–', '—', '˜', '™', 'š', '›', 'œ', 0, 'ž', 'Ÿ',
+}
+
+// windows1252Byte encodes r in Windows-1252.
+func windows1252Byte(r rune) (byte, bool) {
+	if r >= 0x80 && r <= 0x9F {
+		return 0, false
+	}
+	if r >= 0 && r <= 0xFF {
+		return byte(r), true
+	}
+	for i, c := range windows1252High {
+		if c != 0 && c == r {
+			return byte(0x80 + i), true
+		}
+	}
+	return 0, false
+}
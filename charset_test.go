@@ -0,0 +1,181 @@
+// Copyright 2025 The Nanoninja Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+package render
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/nanoninja/assert"
+)
+
+func TestTranscoder(t *testing.T) {
+	tests := []struct {
+		name     string
+		charset  string
+		input    string
+		expected string
+	}{
+		{name: "UTF8", charset: "utf-8", input: "café €", expected: "café €"},
+		{name: "ASCII", charset: "us-ascii", input: "plain", expected: "plain"},
+		{name: "Latin1", charset: "iso-8859-1", input: "café ÿ", expected: "caf\xe9 \xff"},
+		{name: "Latin1Alias", charset: "Latin1", input: "é", expected: "\xe9"},
+		{name: "Latin9", charset: "iso-8859-15", input: "€ Œ é", expected: "\xa4 \xbc \xe9"},
+		{name: "Windows1252", charset: "windows-1252", input: "€“ok”…", expected: "\x80\x93ok\x94\x85"},
+		{name: "UTF16LE", charset: "utf-16le", input: "aé", expected: "\xff\xfea\x00\xe9\x00"},
+		{name: "UTF16BE", charset: "utf-16be", input: "a😀", expected: "\xfe\xff\x00a\xd8\x3d\xde\x00"},
+		{name: "UTF16DefaultsToBigEndian", charset: "utf-16", input: "a", expected: "\xfe\xff\x00a"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var w bytes.Buffer
+
+			tw, err := Transcoder(&w, NewOptions().Use(Mime("text/plain", tt.charset)))
+			assert.Nil(t, err)
+
+			_, err = tw.Write([]byte(tt.input))
+			assert.Nil(t, err)
+			assert.Nil(t, tw.Close())
+			assert.Equals(t, w.String(), tt.expected)
+		})
+	}
+}
+
+func TestTranscoder_Policies(t *testing.T) {
+	transcode := func(contentType, input string, opts ...func(*Options)) (string, error) {
+		var w bytes.Buffer
+
+		options := NewOptions().Use(Header(func(h HeaderOptions) { h.Set("Content-Type", contentType) })).Use(opts...)
+		tw, err := Transcoder(&w, options)
+		if err != nil {
+			return "", err
+		}
+		if _, err := tw.Write([]byte(input)); err != nil {
+			return "", err
+		}
+		err = tw.Close()
+		return w.String(), err
+	}
+
+	t.Run("ErrorByDefault", func(t *testing.T) {
+		_, err := transcode("text/plain; charset=iso-8859-1", "price: 5€")
+
+		assert.ErrorIs(t, err, ErrUnrepresentable)
+		assert.StringContains(t, err.Error(), "U+20AC")
+	})
+
+	t.Run("Replace", func(t *testing.T) {
+		out, err := transcode("text/plain; charset=iso-8859-1", "5€ é", OnUnrepresentable(EncodeReplace))
+
+		assert.Nil(t, err)
+		assert.Equals(t, out, "5? \xe9")
+	})
+
+	t.Run("NCRForHTML", func(t *testing.T) {
+		out, err := transcode("text/html; charset=windows-1252", "<p>→ €</p>", OnUnrepresentable(EncodeNCR))
+
+		assert.Nil(t, err)
+		assert.Equals(t, out, "<p>&#8594; \x80</p>")
+	})
+
+	t.Run("NCRForXMLSuffix", func(t *testing.T) {
+		out, err := transcode("application/atom+xml; charset=us-ascii", "é", OnUnrepresentable(EncodeNCR))
+
+		assert.Nil(t, err)
+		assert.Equals(t, out, "&#233;")
+	})
+
+	t.Run("NCRFallsBackToReplaceForOtherTypes", func(t *testing.T) {
+		out, err := transcode("text/csv; charset=us-ascii", "é", OnUnrepresentable(EncodeNCR))
+
+		assert.Nil(t, err)
+		assert.Equals(t, out, "?")
+	})
+
+	t.Run("UnsupportedCharset", func(t *testing.T) {
+		_, err := transcode("text/plain; charset=shift_jis", "x")
+
+		assert.ErrorIs(t, err, ErrUnsupportedCharset)
+	})
+
+	t.Run("InvalidUTF8IsReplaced", func(t *testing.T) {
+		out, err := transcode("text/plain; charset=utf-16le", "a\xffb")
+
+		assert.Nil(t, err)
+		assert.Equals(t, out, "\xff\xfea\x00\xfd\xffb\x00")
+	})
+}
+
+func TestTranscoder_SplitWrites(t *testing.T) {
+	t.Run("JoinsSequencesAcrossWrites", func(t *testing.T) {
+		var w bytes.Buffer
+
+		tw, err := Transcoder(&w, NewOptions().Use(Mime("text/plain", "iso-8859-1")))
+		assert.Nil(t, err)
+
+		input := []byte("café")
+		for _, b := range input {
+			n, err := tw.Write([]byte{b})
+			assert.Nil(t, err)
+			assert.Equals(t, n, 1)
+		}
+		assert.Nil(t, tw.Close())
+		assert.Equals(t, w.String(), "caf\xe9")
+	})
+
+	t.Run("CloseReportsTruncatedSequence", func(t *testing.T) {
+		var w bytes.Buffer
+
+		tw, err := Transcoder(&w, NewOptions().Use(Mime("text/plain", "iso-8859-1")))
+		assert.Nil(t, err)
+
+		_, err = tw.Write([]byte("caf\xc3"))
+		assert.Nil(t, err)
+		assert.ErrorIs(t, tw.Close(), ErrUnrepresentable)
+	})
+}
+
+func TestTranscoder_Renderers(t *testing.T) {
+	t.Run("CSV", func(t *testing.T) {
+		var w bytes.Buffer
+
+		err := CSV().Render(&w, [][]string{{"Müller", "12,50 €"}}, Mime("text/csv", "windows-1252"))
+
+		assert.Nil(t, err)
+		assert.Equals(t, w.String(), "M\xfcller,\"12,50 \x80\"\n")
+	})
+
+	t.Run("Text", func(t *testing.T) {
+		var w bytes.Buffer
+
+		err := Text().Render(&w, "Grüße", Mime("text/plain", "iso-8859-15"))
+
+		assert.Nil(t, err)
+		assert.Equals(t, w.String(), "Gr\xfc\xdfe")
+	})
+
+	t.Run("JSONP", func(t *testing.T) {
+		var w bytes.Buffer
+
+		err := NewJSON(JSONConfig{Padding: "cb"}).Render(&w, "é", Mime("application/javascript", "iso-8859-1"))
+
+		assert.Nil(t, err)
+		assert.Equals(t, w.String(), "cb(\"\xe9\"\n)")
+	})
+
+	t.Run("XMLDeclaresEncoding", func(t *testing.T) {
+		var w bytes.Buffer
+
+		err := NewXML(XMLConfig{Header: true}).Render(&w, defaultsItem{A: 1}, Mime("application/xml", "iso-8859-1"))
+
+		assert.Nil(t, err)
+		assert.Equals(t, w.String(), `<?xml version="1.0" encoding="ISO-8859-1"?>`+"\n<defaultsItem><A>1</A></defaultsItem>")
+	})
+
+	t.Run("ReturnsErrorForUnrepresentableData", func(t *testing.T) {
+		err := Text().Render(&bytes.Buffer{}, "日本", Mime("text/plain", "iso-8859-1"))
+
+		assert.ErrorIs(t, err, ErrUnrepresentable)
+	})
+}
@@ -0,0 +1,212 @@
+// Copyright 2025 The Nanoninja Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+package render
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"unicode"
+)
+
+// configEntry is a key of a configuration file with its text value.
+type configEntry struct {
+	key   string
+	value string
+}
+
+// configSection is a group of entries, identified by the path of names
+// leading to it from the root.
+type configSection struct {
+	path    []string
+	entries []configEntry
+}
+
+// configWalker flattens structs and maps into configuration sections.
+type configWalker struct {
+	// tag is the struct tag key naming fields, such as "ini".
+	tag string
+
+	// fieldName returns the key of a field without name in its tag.
+	fieldName func(name string) string
+
+	sections []*configSection
+	visiting map[valueRef]bool // References walked by the current path
+}
+
+// walk flattens data into sections. The first section is the root, and
+// the others follow in the order they are found, each one before its
+// nested sections. Data must be a struct or a map, and values referring
+// to themselves return an error wrapping ErrInvalidData.
+func (c *configWalker) walk(data any) ([]*configSection, error) {
+	if v := indirectValue(reflect.ValueOf(data)); !isConfigSection(v) {
+		return nil, ErrInvalidData
+	}
+	c.sections = nil
+	c.visiting = make(map[valueRef]bool)
+	if err := c.section(nil, reflect.ValueOf(data)); err != nil {
+		return nil, err
+	}
+	return c.sections, nil
+}
+
+// section adds the section of the struct or map v, followed by the
+// sections of its nested structs and maps.
+func (c *configWalker) section(path []string, v reflect.Value) error {
+	if ref, ok := valueReference(v); ok {
+		if c.visiting[ref] {
+			return fmt.Errorf("%w: cycle at %s", ErrInvalidData, strings.Join(path, "."))
+		}
+		c.visiting[ref] = true
+		defer delete(c.visiting, ref)
+	}
+	v = indirectValue(v)
+	sec := &configSection{path: path}
+	c.sections = append(c.sections, sec)
+
+	type nested struct {
+		name  string
+		value reflect.Value
+	}
+	var children []nested
+
+	add := func(name string, value reflect.Value) error {
+		if isConfigSection(indirectValue(value)) {
+			children = append(children, nested{name, value})
+			return nil
+		}
+		text, err := configValue(indirectValue(value))
+		if err != nil {
+			return fmt.Errorf("%w: %s", err, strings.Join(append(path, name), "."))
+		}
+		sec.entries = append(sec.entries, configEntry{key: name, value: text})
+		return nil
+	}
+
+	switch v.Kind() {
+	case reflect.Map:
+		for _, key := range sortedMapKeys(v) {
+			if err := add(fmt.Sprint(key.Interface()), v.MapIndex(key)); err != nil {
+				return err
+			}
+		}
+	case reflect.Struct:
+		typ := v.Type()
+		for i := 0; i < typ.NumField(); i++ {
+			field := typ.Field(i)
+			if !field.IsExported() {
+				continue
+			}
+			name, omitEmpty, skip := fieldTag(field, c.tag)
+			if skip || omitEmpty && v.Field(i).IsZero() {
+				continue
+			}
+			if name == "" {
+				name = c.fieldName(field.Name)
+			}
+			if err := add(name, v.Field(i)); err != nil {
+				return err
+			}
+		}
+	}
+
+	for _, child := range children {
+		childPath := append(append([]string(nil), path...), child.name)
+		if err := c.section(childPath, child.value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// isConfigSection reports whether v is written as a section, being a
+// struct or a map.
+func isConfigSection(v reflect.Value) bool {
+	return v.IsValid() && !isScalarValue(v) && v.Kind() != reflect.Slice && v.Kind() != reflect.Array
+}
+
+// configValue formats a scalar value, or a slice of scalars joined by
+// commas. Nil values are empty, and items holding a comma return an error
+// wrapping ErrInvalidData since they could not be told apart.
+func configValue(v reflect.Value) (string, error) {
+	if !v.IsValid() || isScalarValue(v) {
+		return textValue(v), nil
+	}
+	items := make([]string, v.Len())
+	for i := range items {
+		item := indirectValue(v.Index(i))
+		if item.IsValid() && !isScalarValue(item) {
+			return "", fmt.Errorf("%w: nested values in list", ErrInvalidData)
+		}
+		items[i] = textValue(item)
+		if strings.Contains(items[i], ",") {
+			return "", fmt.Errorf("%w: comma in list item %q", ErrInvalidData, items[i])
+		}
+	}
+	return strings.Join(items, ","), nil
+}
+
+// headerMarker returns the marker of the header comment, which is the
+// comment marker of the format options or def when there is none. Since
+// the marker only applies to the header, the prefix set along with it is
+// removed from o so that the entries are not commented out. Markers other
+// than those in allowed return an error wrapping ErrInvalidParam.
+func headerMarker(o *Options, def string, allowed ...string) (string, error) {
+	marker := o.format.comment
+	if marker == "" {
+		return def, nil
+	}
+	o.format.prefix = ""
+	o.format.comment = ""
+	for _, m := range allowed {
+		if marker == m {
+			return marker, nil
+		}
+	}
+	return "", fmt.Errorf("%w: comment marker %q", ErrInvalidParam, marker)
+}
+
+// headerLineBreaks splits header comments on every line break parsers
+// recognize, so that no line escapes its comment marker.
+var headerLineBreaks = strings.NewReplacer("\r\n", "\n", "\r", "\n")
+
+// writeHeaderComment writes the header comment of o, if any, with each
+// line starting with marker, followed by an empty line.
+func writeHeaderComment(b *strings.Builder, o *Options, marker string, escape func(string) string) {
+	text := o.format.header
+	if text == "" {
+		return
+	}
+	for _, line := range strings.Split(headerLineBreaks.Replace(text), "\n") {
+		b.WriteString(marker)
+		if line != "" {
+			b.WriteString(" " + escape(line))
+		}
+		b.WriteByte('\n')
+	}
+	b.WriteByte('\n')
+}
+
+// identityName returns name unchanged.
+func identityName(name string) string {
+	return name
+}
+
+// upperSnakeCase converts a Go identifier such as "MaxConns" or "DBHost"
+// to upper snake case, such as "MAX_CONNS" or "DB_HOST".
+func upperSnakeCase(name string) string {
+	runes := []rune(name)
+	var b strings.Builder
+	for i, r := range runes {
+		if i > 0 && unicode.IsUpper(r) {
+			prev := runes[i-1]
+			nextLower := i+1 < len(runes) && unicode.IsLower(runes[i+1])
+			if unicode.IsLower(prev) || unicode.IsDigit(prev) || unicode.IsUpper(prev) && nextLower {
+				b.WriteByte('_')
+			}
+		}
+		b.WriteRune(unicode.ToUpper(r))
+	}
+	return b.String()
+}
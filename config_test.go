@@ -0,0 +1,120 @@
+// Copyright 2025 The Nanoninja Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+package render
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/nanoninja/assert"
+)
+
+// configServer is a configuration struct shared by the INI, Dotenv and
+// Properties tests.
+type configServer struct {
+	Name     string   `ini:"name" properties:"name"`
+	Port     int      `ini:"port" properties:"port"`
+	Hosts    []string `ini:"hosts" properties:"hosts"`
+	Password string   `ini:"-" env:"-" properties:"-"`
+	Comment  string   `ini:"comment,omitempty" env:",omitempty" properties:"comment,omitempty"`
+	Database struct {
+		URL  string `ini:"url" env:"URL" properties:"url"`
+		Pool struct {
+			MaxConns int `ini:"max_conns" properties:"maxConns"`
+		} `ini:"pool" properties:"pool"`
+	} `ini:"database" properties:"database"`
+}
+
+// newConfigServer returns a configuration with every field set.
+func newConfigServer() configServer {
+	var c configServer
+	c.Name = "api"
+	c.Port = 8080
+	c.Hosts = []string{"a.local", "b.local"}
+	c.Password = "secret"
+	c.Database.URL = "postgres://db/app"
+	c.Database.Pool.MaxConns = 10
+	return c
+}
+
+func TestConfigWalker(t *testing.T) {
+	t.Run("OrdersSectionsBeforeTheirChildren", func(t *testing.T) {
+		walker := configWalker{tag: "ini", fieldName: identityName}
+
+		sections, err := walker.walk(map[string]any{
+			"b":    map[string]any{"x": 1, "c": map[string]int{"y": 2}},
+			"a":    map[string]int{"z": 3},
+			"root": true,
+		})
+
+		assert.Nil(t, err)
+		assert.Equals(t, len(sections), 4)
+		assert.Equals(t, sections[0].entries, []configEntry{{"root", "true"}})
+		assert.Equals(t, sections[1].path, []string{"a"})
+		assert.Equals(t, sections[2].path, []string{"b"})
+		assert.Equals(t, sections[2].entries, []configEntry{{"x", "1"}})
+		assert.Equals(t, sections[3].path, []string{"b", "c"})
+	})
+
+	t.Run("RejectsNestedValuesInLists", func(t *testing.T) {
+		walker := configWalker{tag: "ini", fieldName: identityName}
+
+		_, err := walker.walk(map[string]any{"list": []map[string]int{{"a": 1}}})
+
+		assert.ErrorIs(t, err, ErrInvalidData)
+	})
+
+	t.Run("RejectsCycles", func(t *testing.T) {
+		renderers := map[string]Renderer{"INI": INI(), "Dotenv": Dotenv(), "Properties": Properties()}
+
+		for name, r := range renderers {
+			for kind, data := range newCyclicData() {
+				t.Run(name+kind, func(t *testing.T) {
+					var w bytes.Buffer
+
+					err := r.Render(&w, data)
+
+					assert.ErrorIs(t, err, ErrInvalidData)
+					assert.Equals(t, w.Len(), 0)
+				})
+			}
+		}
+	})
+
+	t.Run("WalksSharedReferences", func(t *testing.T) {
+		walker := configWalker{tag: "ini", fieldName: identityName}
+		shared := map[string]int{"x": 1}
+
+		sections, err := walker.walk(map[string]any{"a": shared, "b": shared})
+
+		assert.Nil(t, err)
+		assert.Equals(t, len(sections), 3)
+	})
+
+	t.Run("RejectsNonRecordData", func(t *testing.T) {
+		walker := configWalker{tag: "ini", fieldName: identityName}
+
+		for _, data := range []any{nil, "text", 42, []string{"a"}} {
+			_, err := walker.walk(data)
+			assert.ErrorIs(t, err, ErrInvalidData)
+		}
+	})
+}
+
+func TestUpperSnakeCase(t *testing.T) {
+	tests := map[string]string{
+		"Name":      "NAME",
+		"MaxConns":  "MAX_CONNS",
+		"DBHost":    "DB_HOST",
+		"HTTPPort":  "HTTP_PORT",
+		"UserID":    "USER_ID",
+		"Retry3Max": "RETRY3_MAX",
+		"already":   "ALREADY",
+	}
+	for name, want := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equals(t, upperSnakeCase(name), want)
+		})
+	}
+}
@@ -4,6 +4,7 @@
 package render
 
 import (
+	"bytes"
 	"context"
 	"encoding/csv"
 	"io"
@@ -30,24 +31,53 @@ func (r *csvRenderer) Render(w io.Writer, data any, opts ...func(*Options)) erro
 // RenderContext writes CSV data with context support.
 // It accepts only [][]string data type and uses encoding/csv.Writer for output.
 // The content type is set to text/csv by default but can be overridden through options.
+// The output is transcoded when the content type declares a charset other
+// than UTF-8, as often expected by spreadsheet and banking imports.
+// The prefix and line ending of the format options apply to each record,
+// while the line breaks of quoted fields are written unchanged.
 func (r *csvRenderer) RenderContext(ctx context.Context, w io.Writer, data any, opts ...func(*Options)) error {
 	if err := CheckContext(ctx); err != nil {
 		return err
 	}
 	options := NewOptions().
 		Use(MimeCSV()).
+		Use(ContextDefaults(ctx)).
 		Use(opts...)
 
-	writer := csv.NewWriter(w)
-	if sep := options.params["separator"]; sep != "" {
-		writer.Comma = rune(sep[0])
+	if err := options.Err(); err != nil {
+		return err
 	}
-	if options.format.lineEnding != "" {
-		writer.UseCRLF = options.format.lineEnding == "\r\n"
+
+	tw, err := OutputWriter(w, options)
+	if err != nil {
+		return err
 	}
 	records, ok := data.([][]string)
 	if !ok {
 		return ErrInvalidData
 	}
-	return writer.WriteAll(records)
+	// Records are encoded one at a time, so that the format options apply
+	// to the record separators but not to the line breaks of quoted fields
+	var buf bytes.Buffer
+	writer := csv.NewWriter(&buf)
+	if sep := SeparatorKey.Get(options); sep != 0 {
+		writer.Comma = sep
+	}
+	for _, record := range records {
+		buf.Reset()
+		if err := writer.Write(record); err != nil {
+			return err
+		}
+		writer.Flush()
+		if err := writer.Error(); err != nil {
+			return err
+		}
+		if _, err := writeData(tw, bytes.TrimSuffix(buf.Bytes(), []byte("\n"))); err != nil {
+			return err
+		}
+		if _, err := io.WriteString(tw, "\n"); err != nil {
+			return err
+		}
+	}
+	return tw.Close()
 }
@@ -100,4 +100,22 @@ func TestCSVRenderer(t *testing.T) {
 
 		assert.ErrorIs(t, err, context.Canceled)
 	})
+	t.Run("AppliesPrefixAndIgnoresIndent", func(t *testing.T) {
+		var w bytes.Buffer
+
+		err := CSV().Render(&w, [][]string{{"a", "b"}, {"c", "d"}}, Format(Prefix("# "), Indent("\t"), Pretty()))
+
+		assert.Nil(t, err)
+		assert.Equals(t, w.String(), "# a,b\n# c,d\n")
+	})
+
+	t.Run("KeepsLineBreaksOfQuotedFields", func(t *testing.T) {
+		var w bytes.Buffer
+		data := [][]string{{"a", "line1\nline2"}, {"b", "c"}}
+
+		err := CSV().Render(&w, data, Format(Prefix("> "), LineEnding("\r\n")))
+
+		assert.Nil(t, err)
+		assert.Equals(t, w.String(), "> a,\"line1\nline2\"\r\n> b,c\r\n")
+	})
 }
@@ -0,0 +1,32 @@
+// Copyright 2025 The Nanoninja Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+package render
+
+import "reflect"
+
+// valueRef identifies a reference value, to detect cycles while walking
+// nested data.
+type valueRef struct {
+	typ reflect.Type
+	ptr uintptr
+}
+
+// valueReference returns the reference identifying v, for pointers, maps
+// and slices.
+func valueReference(v reflect.Value) (valueRef, bool) {
+	for v.IsValid() && v.Kind() == reflect.Interface {
+		v = v.Elem()
+	}
+	if !v.IsValid() {
+		return valueRef{}, false
+	}
+	switch v.Kind() {
+	case reflect.Pointer, reflect.Map, reflect.Slice:
+		if v.IsNil() {
+			return valueRef{}, false
+		}
+		return valueRef{typ: v.Type(), ptr: v.Pointer()}, true
+	}
+	return valueRef{}, false
+}
@@ -0,0 +1,120 @@
+// Copyright 2025 The Nanoninja Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+package render
+
+import (
+	"context"
+	"io"
+)
+
+// defaultsKey is the context key holding request-scoped default options.
+type defaultsKey struct{}
+
+// WithDefaults returns a copy of ctx carrying default options applied by
+// every renderer receiving the context. Defaults already carried by ctx
+// are kept and applied first, so that nested middleware can refine them.
+//
+// Renderers apply options in the following order:
+//  1. Their own defaults, such as the content type
+//  2. Options bound to the renderer with Bind
+//  3. Context defaults, in the order they were added
+//  4. Options given to Render or RenderContext
+//
+// Example:
+//
+//	func PrettyMiddleware(next http.Handler) http.Handler {
+//	    return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+//	        if r.URL.Query().Get("pretty") == "1" {
+//	            r = r.WithContext(render.WithDefaults(r.Context(), render.Format(render.Pretty())))
+//	        }
+//	        next.ServeHTTP(w, r)
+//	    })
+//	}
+func WithDefaults(ctx context.Context, opts ...func(*Options)) context.Context {
+	current := Defaults(ctx)
+	defaults := make([]func(*Options), 0, len(current)+len(opts))
+	defaults = append(defaults, current...)
+	defaults = append(defaults, opts...)
+	return context.WithValue(ctx, defaultsKey{}, defaults)
+}
+
+// Defaults returns the default options carried by ctx, if any.
+func Defaults(ctx context.Context) []func(*Options) {
+	if ctx == nil {
+		return nil
+	}
+	defaults, _ := ctx.Value(defaultsKey{}).([]func(*Options))
+	return defaults
+}
+
+// ContextDefaults returns an option function applying the default options
+// carried by ctx. Renderers use it between their own defaults and the
+// options given by the caller:
+//
+//	options := render.NewOptions().
+//	    Use(render.MimeJSON()).
+//	    Use(render.ContextDefaults(ctx)).
+//	    Use(opts...)
+func ContextDefaults(ctx context.Context) func(*Options) {
+	return With(Defaults(ctx)...)
+}
+
+// BoundRenderer is a renderer with default options bound at construction.
+type BoundRenderer struct {
+	renderer Renderer         // The underlying renderer
+	opts     []func(*Options) // Options bound to the renderer
+}
+
+// Bind returns a renderer applying opts by default to every rendering of r.
+// Bound options take precedence over the defaults of r, but are overridden
+// by context defaults set with WithDefaults and by the options given to
+// Render or RenderContext. The merged result can be inspected with
+// CaptureOptions.
+//
+// Binding an already bound renderer refines its options: the outer
+// binding overrides the inner one.
+//
+// Bound options are carried to r as context defaults, ahead of those
+// already set with WithDefaults, so that renderers nested in r, such as
+// the error view of a Fallback, receive them too. A RendererFunc applies
+// them with ContextDefaults, as built-in renderers do.
+//
+// Example:
+//
+//	api := render.Bind(render.JSON(),
+//	    render.Format(render.Pretty()),
+//	    render.MimeUTF8("application/vnd.api+json"),
+//	)
+//	api.Render(w, data)
+func Bind(r Renderer, opts ...func(*Options)) *BoundRenderer {
+	return &BoundRenderer{
+		renderer: r,
+		opts:     opts,
+	}
+}
+
+// Kind returns the kind of the underlying renderer, so that observers
+// report bound renderers under the same name.
+func (r *BoundRenderer) Kind() string {
+	return KindOf(r.renderer)
+}
+
+// Render renders data with the bound options using a background context.
+func (r *BoundRenderer) Render(w io.Writer, data any, opts ...func(*Options)) error {
+	return r.RenderContext(context.Background(), w, data, opts...)
+}
+
+// RenderContext renders data with the bound options and context support.
+func (r *BoundRenderer) RenderContext(ctx context.Context, w io.Writer, data any, opts ...func(*Options)) error {
+	if err := CheckContext(ctx); err != nil {
+		return err
+	}
+	current := Defaults(ctx)
+	defaults := make([]func(*Options), 0, len(r.opts)+len(current))
+	defaults = append(defaults, r.opts...)
+	defaults = append(defaults, current...)
+
+	ctx = context.WithValue(ctx, defaultsKey{}, defaults)
+	return r.renderer.RenderContext(ctx, w, data, opts...)
+}
@@ -0,0 +1,232 @@
+// Copyright 2025 The Nanoninja Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+package render
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/nanoninja/assert"
+)
+
+func TestWithDefaults(t *testing.T) {
+	t.Run("CarriesOptions", func(t *testing.T) {
+		ctx := WithDefaults(context.Background(), Name("page"))
+
+		opts := NewOptions().Use(ContextDefaults(ctx))
+
+		assert.Equals(t, opts.Name(), "page")
+	})
+
+	t.Run("AccumulatesDefaults", func(t *testing.T) {
+		parent := WithDefaults(context.Background(), Name("parent"), Param("a", "1"))
+		child := WithDefaults(parent, Name("child"))
+
+		opts := NewOptions().Use(ContextDefaults(child))
+
+		assert.Equals(t, opts.Name(), "child")
+		assert.Equals(t, opts.Params()["a"], "1")
+		assert.Len(t, Defaults(parent), 2)
+		assert.Len(t, Defaults(child), 3)
+	})
+
+	t.Run("DoesNotShareBackingArray", func(t *testing.T) {
+		parent := WithDefaults(context.Background(), Name("parent"))
+		first := WithDefaults(parent, Name("first"))
+		second := WithDefaults(parent, Name("second"))
+
+		assert.Equals(t, NewOptions().Use(ContextDefaults(first)).Name(), "first")
+		assert.Equals(t, NewOptions().Use(ContextDefaults(second)).Name(), "second")
+	})
+
+	t.Run("HandlesContextWithoutDefaults", func(t *testing.T) {
+		opts := NewOptions().Use(ContextDefaults(context.Background()))
+
+		assert.Len(t, Defaults(context.Background()), 0)
+		assert.Equals(t, opts.Name(), "")
+	})
+}
+
+// defaultsItem is a named type so that it can be encoded as XML.
+type defaultsItem struct{ A int }
+
+func TestWithDefaults_Renderers(t *testing.T) {
+	ctx := WithDefaults(context.Background(),
+		Format(Pretty()),
+		Header(func(h HeaderOptions) { h.Set("X-Request-Id", "42") }),
+	)
+
+	renderers := map[string]struct {
+		renderer Renderer
+		data     any
+	}{
+		"Text":   {Text(), "hello"},
+		"JSON":   {JSON(), map[string]int{"a": 1}},
+		"XML":    {XML(), defaultsItem{A: 1}},
+		"CSV":    {CSV(), [][]string{{"a"}}},
+		"Binary": {Binary(), []byte("data")},
+		"Error":  {ErrorPage(), ErrorData{Status: 500}},
+	}
+	for name, tt := range renderers {
+		t.Run(name, func(t *testing.T) {
+			var opts *Options
+
+			err := tt.renderer.RenderContext(ctx, &bytes.Buffer{}, tt.data, CaptureOptions(&opts))
+
+			assert.Nil(t, err)
+			assert.True(t, opts.Format().Pretty())
+			assert.Equals(t, opts.Header().Get("X-Request-Id"), "42")
+		})
+	}
+
+	t.Run("AppliedAfterRendererDefaults", func(t *testing.T) {
+		var opts *Options
+
+		ctx := WithDefaults(context.Background(), MimeUTF8("application/vnd.api+json"))
+
+		err := JSON().RenderContext(ctx, &bytes.Buffer{}, 1, CaptureOptions(&opts))
+
+		assert.Nil(t, err)
+		assert.Equals(t, opts.ContentType(), "application/vnd.api+json; charset=utf-8")
+	})
+
+	t.Run("OverriddenByCallOptions", func(t *testing.T) {
+		var w bytes.Buffer
+
+		ctx := WithDefaults(context.Background(), Format(Pretty()))
+
+		err := JSON().RenderContext(ctx, &w, map[string]int{"a": 1}, func(o *Options) { o.format.pretty = false })
+
+		assert.Nil(t, err)
+		assert.Equals(t, w.String(), "{\"a\":1}\n")
+	})
+}
+
+var (
+	_ Renderer = Bind(nil)
+	_ Renderer = (*BoundRenderer)(nil)
+)
+
+func TestBind(t *testing.T) {
+	t.Run("AppliesBoundOptions", func(t *testing.T) {
+		var w bytes.Buffer
+
+		err := Bind(JSON(), Format(Pretty())).Render(&w, map[string]int{"a": 1})
+
+		assert.Nil(t, err)
+		assert.Equals(t, w.String(), "{\n  \"a\": 1\n}\n")
+	})
+
+	t.Run("FollowsPrecedenceOrder", func(t *testing.T) {
+		var opts *Options
+
+		setParams := func(keys ...string) func(*Options) {
+			return func(o *Options) {
+				for _, k := range keys {
+					o.params[k] = "bound"
+				}
+			}
+		}
+		renderer := Bind(JSON(),
+			MimeUTF8("application/vnd.api+json"),
+			setParams("bound", "context", "call"),
+		)
+		ctx := WithDefaults(context.Background(),
+			Param("context", "context"),
+			Param("call", "context"),
+		)
+
+		err := renderer.RenderContext(ctx, &bytes.Buffer{}, nil, Param("call", "call"), CaptureOptions(&opts))
+
+		assert.Nil(t, err)
+		assert.Equals(t, opts.ContentType(), "application/vnd.api+json; charset=utf-8")
+		assert.Equals(t, opts.Params()["bound"], "bound")
+		assert.Equals(t, opts.Params()["context"], "context")
+		assert.Equals(t, opts.Params()["call"], "call")
+	})
+
+	t.Run("AppliesBoundOptionsToRendererFuncs", func(t *testing.T) {
+		var name string
+
+		renderer := Bind(RendererFunc(func(ctx context.Context, _ io.Writer, _ any, opts ...func(*Options)) error {
+			name = NewOptions().Use(ContextDefaults(ctx)).Use(opts...).Name()
+			return nil
+		}), Name("bound"))
+
+		err := renderer.Render(&bytes.Buffer{}, nil)
+
+		assert.Nil(t, err)
+		assert.Equals(t, name, "bound")
+	})
+
+	t.Run("CarriesDefaultsToNestedRenderers", func(t *testing.T) {
+		var params map[string]string
+
+		onError := RendererFunc(func(ctx context.Context, _ io.Writer, _ any, opts ...func(*Options)) error {
+			params = NewOptions().Use(ContextDefaults(ctx)).Use(opts...).Params()
+			return nil
+		})
+		renderer := Bind(Fallback(&mockRenderer{err: errors.New("boom")}, onError), Param("theme", "dark"))
+		ctx := WithDefaults(context.Background(), Param("tenant", "acme"))
+
+		err := renderer.RenderContext(ctx, &bytes.Buffer{}, nil)
+
+		assert.Nil(t, err)
+		assert.Equals(t, params["theme"], "dark")
+		assert.Equals(t, params["tenant"], "acme")
+	})
+
+	t.Run("AppliesDefaultsOnce", func(t *testing.T) {
+		var calls int
+
+		count := func(*Options) { calls++ }
+		ctx := WithDefaults(context.Background(), count)
+
+		err := Bind(Text(), count).RenderContext(ctx, &bytes.Buffer{}, "x")
+
+		assert.Nil(t, err)
+		assert.Equals(t, calls, 2)
+	})
+
+	t.Run("DoesNotLeakIntoContext", func(t *testing.T) {
+		var opts *Options
+
+		ctx := WithDefaults(context.Background(), Name("context"))
+		err := Bind(Text(), Param("bound", "yes")).RenderContext(ctx, &bytes.Buffer{}, "x")
+		assert.Nil(t, err)
+
+		err = Text().RenderContext(ctx, &bytes.Buffer{}, "x", CaptureOptions(&opts))
+		assert.Nil(t, err)
+		assert.Equals(t, opts.Params()["bound"], "")
+		assert.Len(t, Defaults(ctx), 1)
+	})
+
+	t.Run("OuterBindingOverridesInner", func(t *testing.T) {
+		var opts *Options
+
+		renderer := Bind(Bind(Text(), Name("inner"), Param("a", "inner")), Name("outer"))
+
+		err := renderer.Render(&bytes.Buffer{}, "x", CaptureOptions(&opts))
+
+		assert.Nil(t, err)
+		assert.Equals(t, opts.Name(), "outer")
+		assert.Equals(t, opts.Params()["a"], "inner")
+	})
+
+	t.Run("KeepsRendererKind", func(t *testing.T) {
+		assert.Equals(t, KindOf(Bind(JSON())), KindOf(JSON()))
+	})
+
+	t.Run("RespectsContextCancellation", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		err := Bind(Text()).RenderContext(ctx, &bytes.Buffer{}, "x")
+
+		assert.ErrorIs(t, err, context.Canceled)
+	})
+}
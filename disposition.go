@@ -0,0 +1,152 @@
+// Copyright 2025 The Nanoninja Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+package render
+
+import (
+	"mime"
+	"path"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// extensions maps common content types to their preferred file extension.
+// It takes precedence over the system MIME tables, which vary between
+// platforms and often list several extensions for the same type.
+var extensions = map[string]string{
+	"application/json":         ".json",
+	"application/xml":          ".xml",
+	"application/yaml":         ".yaml",
+	"application/pdf":          ".pdf",
+	"application/octet-stream": ".bin",
+	"application/zip":          ".zip",
+	"text/plain":               ".txt",
+	"text/html":                ".html",
+	"text/csv":                 ".csv",
+	"text/xml":                 ".xml",
+	"image/png":                ".png",
+	"image/jpeg":               ".jpg",
+	"image/gif":                ".gif",
+
+	"application/vnd.openxmlformats-officedocument.spreadsheetml.sheet": ".xlsx",
+}
+
+// Attachment returns an option function that sets the Content-Disposition
+// header so that clients download the content as a file.
+// The filename is sanitized and encoded following RFC 6266 and RFC 5987:
+// the filename parameter holds an ASCII fallback while filename* holds
+// the exact UTF-8 name. When the filename has no extension, one is derived
+// from the content type configured so far, so Attachment should be placed
+// after any Mime option.
+//
+// Example:
+//
+//	// Content-Disposition: attachment; filename="r_sum_.csv"; filename*=UTF-8''r%C3%A9sum%C3%A9.csv
+//	render.CSV().Render(w, records, render.Attachment("résumé"), render.WriteResponse(w))
+func Attachment(filename string) func(*Options) {
+	return disposition("attachment", filename)
+}
+
+// Inline returns an option function that sets the Content-Disposition header
+// so that clients display the content, while still suggesting a filename to
+// use if it is saved. See Attachment for the filename handling.
+//
+// Example:
+//
+//	render.Binary().Render(w, pdf, render.Inline("invoice.pdf"), render.WriteResponse(w))
+func Inline(filename string) func(*Options) {
+	return disposition("inline", filename)
+}
+
+// disposition builds a Content-Disposition option of the given type.
+func disposition(kind, filename string) func(*Options) {
+	return func(o *Options) {
+		name := sanitizeFilename(filename)
+		if name != "" && path.Ext(name) == "" {
+			name += extensionFor(o.ContentType())
+		}
+		value := kind
+		if name != "" {
+			value += `; filename="` + asciiFilename(name) + `"; filename*=UTF-8''` + encodeRFC5987(name)
+		}
+		o.header.Set("Content-Disposition", value)
+	}
+}
+
+// sanitizeFilename strips directories, control characters and surrounding
+// spaces and dots from a filename.
+func sanitizeFilename(filename string) string {
+	if i := strings.LastIndexAny(filename, `/\`); i >= 0 {
+		filename = filename[i+1:]
+	}
+	filename = strings.Map(func(r rune) rune {
+		if unicode.IsControl(r) || r == utf8.RuneError {
+			return -1
+		}
+		return r
+	}, filename)
+	return strings.Trim(filename, " .")
+}
+
+// asciiFilename returns an ASCII-only version of name suitable for a
+// quoted-string, replacing other characters with underscores.
+func asciiFilename(name string) string {
+	var b strings.Builder
+	for _, r := range name {
+		switch {
+		case r == '"' || r == '\\':
+			b.WriteByte('\\')
+			b.WriteRune(r)
+		case r > unicode.MaxASCII:
+			b.WriteByte('_')
+		default:
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+// encodeRFC5987 percent-encodes every byte of s that is not an attr-char
+// as defined by RFC 5987.
+func encodeRFC5987(s string) string {
+	const hex = "0123456789ABCDEF"
+
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if isAttrChar(c) {
+			b.WriteByte(c)
+			continue
+		}
+		b.WriteByte('%')
+		b.WriteByte(hex[c>>4])
+		b.WriteByte(hex[c&0x0F])
+	}
+	return b.String()
+}
+
+// isAttrChar reports whether c can appear unencoded in an RFC 5987 value.
+func isAttrChar(c byte) bool {
+	switch {
+	case 'a' <= c && c <= 'z', 'A' <= c && c <= 'Z', '0' <= c && c <= '9':
+		return true
+	}
+	return strings.IndexByte("!#$&+-.^_`|~", c) >= 0
+}
+
+// extensionFor returns the file extension matching a content type,
+// or an empty string if none is known.
+func extensionFor(contentType string) string {
+	mediatype, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return ""
+	}
+	if ext, ok := extensions[mediatype]; ok {
+		return ext
+	}
+	if exts, err := mime.ExtensionsByType(mediatype); err == nil && len(exts) > 0 {
+		return exts[0]
+	}
+	return ""
+}
@@ -0,0 +1,104 @@
+// Copyright 2025 The Nanoninja Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+package render
+
+import (
+	"bytes"
+	"mime"
+	"testing"
+
+	"github.com/nanoninja/assert"
+)
+
+func TestAttachment(t *testing.T) {
+	tests := []struct {
+		name     string
+		filename string
+		opts     []func(*Options)
+		expected string
+	}{
+		{
+			name:     "ASCIIFilename",
+			filename: "report.csv",
+			expected: `attachment; filename="report.csv"; filename*=UTF-8''report.csv`,
+		},
+		{
+			name:     "NonASCIIFilename",
+			filename: "résumé 2025.pdf",
+			expected: `attachment; filename="r_sum_ 2025.pdf"; filename*=UTF-8''r%C3%A9sum%C3%A9%202025.pdf`,
+		},
+		{
+			name:     "StripsPathSeparators",
+			filename: `../../etc\passwd.txt`,
+			expected: `attachment; filename="passwd.txt"; filename*=UTF-8''passwd.txt`,
+		},
+		{
+			name:     "StripsControlCharacters",
+			filename: "bad\r\nname\x00.txt",
+			expected: `attachment; filename="badname.txt"; filename*=UTF-8''badname.txt`,
+		},
+		{
+			name:     "EscapesQuotes",
+			filename: `say "hi".txt`,
+			expected: `attachment; filename="say \"hi\".txt"; filename*=UTF-8''say%20%22hi%22.txt`,
+		},
+		{
+			name:     "DefaultsExtensionFromContentType",
+			filename: "export",
+			opts:     []func(*Options){MimeCSV()},
+			expected: `attachment; filename="export.csv"; filename*=UTF-8''export.csv`,
+		},
+		{
+			name:     "DefaultsExtensionForSpreadsheets",
+			filename: "export",
+			opts:     []func(*Options){Mime("application/vnd.openxmlformats-officedocument.spreadsheetml.sheet")},
+			expected: `attachment; filename="export.xlsx"; filename*=UTF-8''export.xlsx`,
+		},
+		{
+			name:     "EmptyFilename",
+			filename: "",
+			expected: "attachment",
+		},
+		{
+			name:     "FilenameReducedToNothing",
+			filename: "../..",
+			expected: "attachment",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := NewOptions().Use(tt.opts...).Use(Attachment(tt.filename))
+
+			assert.Equals(t, opts.Header().Get("Content-Disposition"), tt.expected)
+		})
+	}
+}
+
+func TestAttachment_ParsesWithMime(t *testing.T) {
+	opts := NewOptions().Use(Attachment("naïve café.txt"))
+
+	disposition, params, err := mime.ParseMediaType(opts.Header().Get("Content-Disposition"))
+
+	assert.Nil(t, err)
+	assert.Equals(t, disposition, "attachment")
+	assert.Equals(t, params["filename"], "naïve café.txt")
+}
+
+func TestInline(t *testing.T) {
+	t.Run("SetsInlineDisposition", func(t *testing.T) {
+		opts := NewOptions().Use(Inline("invoice.pdf"))
+
+		assert.Equals(t, opts.Header().Get("Content-Disposition"), `inline; filename="invoice.pdf"; filename*=UTF-8''invoice.pdf`)
+	})
+
+	t.Run("UsesRendererContentType", func(t *testing.T) {
+		var w bytes.Buffer
+		var opts *Options
+
+		err := JSON().Render(&w, nil, Inline("data"), CaptureOptions(&opts))
+
+		assert.Nil(t, err)
+		assert.Equals(t, opts.Header().Get("Content-Disposition"), `inline; filename="data.json"; filename*=UTF-8''data.json`)
+	})
+}
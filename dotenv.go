@@ -0,0 +1,147 @@
+// Copyright 2025 The Nanoninja Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+package render
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+)
+
+var (
+	// dotenvKeyPattern matches the variable names supported by shells.
+	dotenvKeyPattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+	// dotenvBarePattern matches the values written without quotes.
+	dotenvBarePattern = regexp.MustCompile(`^[A-Za-z0-9_./:@%+,=-]*$`)
+
+	// dotenvValueReplacer escapes the characters interpreted by shells in
+	// double quoted values.
+	dotenvValueReplacer = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "$", `\$`, "`", "\\`")
+)
+
+// DotenvConfig defines configuration options for dotenv rendering.
+type DotenvConfig struct {
+	// Export starts each line with "export ", so that the file can be
+	// sourced by POSIX shells.
+	Export bool
+}
+
+// dotenvRenderer implements the rendering of structs and maps as .env files.
+type dotenvRenderer struct {
+	config DotenvConfig
+}
+
+// Dotenv creates a new dotenv renderer writing KEY=value lines.
+// This is the recommended constructor for most use cases.
+func Dotenv() Renderer {
+	return NewDotenv(DotenvConfig{})
+}
+
+// NewDotenv creates a dotenv renderer with custom configuration.
+func NewDotenv(c DotenvConfig) Renderer {
+	return &dotenvRenderer{config: c}
+}
+
+// Render writes data as a .env file using a background context.
+// Data must be a struct or a map. Struct fields are named by the "env"
+// struct tag when set, or else by their name in upper snake case, such as
+// MAX_CONNS for MaxConns. They are skipped when the tag is "-" and when
+// empty with the "omitempty" tag option. Maps are sorted by key and their
+// keys used as-is. Nested structs and maps are flattened by joining names
+// with underscores, such as DATABASE_HOST, and slices of scalars are
+// joined by commas, items holding a comma returning an error wrapping
+// ErrInvalidData.
+func (r *dotenvRenderer) Render(w io.Writer, data any, opts ...func(*Options)) error {
+	return r.RenderContext(context.Background(), w, data, opts...)
+}
+
+// RenderContext writes data as a .env file with context support.
+// Values made of safe characters are written as-is. Others are single
+// quoted, so that they are not expanded, or double quoted with escapes
+// when they hold single quotes. Line breaks are written within the quotes,
+// so that files are read the same by dotenv parsers and by shells
+// sourcing them. Names that are not
+// valid shell variable names return an error wrapping ErrInvalidKey before
+// anything is written.
+// The header comment set with HeaderComment is written first with "#",
+// the only comment marker of dotenv files; other markers set with Comment
+// return an error wrapping ErrInvalidParam. The content type is text/plain
+// by default.
+func (r *dotenvRenderer) RenderContext(ctx context.Context, w io.Writer, data any, opts ...func(*Options)) error {
+	if err := CheckContext(ctx); err != nil {
+		return err
+	}
+	options := NewOptions().
+		Use(MimeTextPlain()).
+		Use(ContextDefaults(ctx)).
+		Use(opts...)
+
+	if err := options.Err(); err != nil {
+		return err
+	}
+
+	marker, err := headerMarker(options, "#", "#")
+	if err != nil {
+		return err
+	}
+
+	walker := configWalker{tag: "env", fieldName: upperSnakeCase}
+	sections, err := walker.walk(data)
+	if err != nil {
+		return err
+	}
+
+	var b strings.Builder
+	writeHeaderComment(&b, options, marker, identityName)
+	header := b.String()
+
+	var lines []string
+	for _, sec := range sections {
+		for _, entry := range sec.entries {
+			key := strings.Join(append(append([]string(nil), sec.path...), entry.key), "_")
+			if !dotenvKeyPattern.MatchString(key) {
+				return fmt.Errorf("%w: environment variable %q", ErrInvalidKey, key)
+			}
+			line := key + "=" + dotenvValue(entry.value)
+			if r.config.Export {
+				line = "export " + line
+			}
+			lines = append(lines, line)
+		}
+	}
+
+	out, err := OutputWriter(w, options)
+	if err != nil {
+		return err
+	}
+	if _, err := io.WriteString(out, header); err != nil {
+		return err
+	}
+	// Quoted values may hold line breaks, which are written unchanged
+	for _, line := range lines {
+		if _, err := writeData(out, []byte(line)); err != nil {
+			return err
+		}
+		if _, err := io.WriteString(out, "\n"); err != nil {
+			return err
+		}
+	}
+	return out.Close()
+}
+
+// dotenvValue quotes a value when it holds characters interpreted by
+// dotenv parsers or shells. Line breaks and other control characters are
+// kept as-is within the quotes, as shells have no escape for them.
+func dotenvValue(s string) string {
+	switch {
+	case dotenvBarePattern.MatchString(s):
+		return s
+	case !strings.Contains(s, "'"):
+		return "'" + s + "'"
+	}
+	return `"` + dotenvValueReplacer.Replace(s) + `"`
+}
@@ -0,0 +1,108 @@
+// Copyright 2025 The Nanoninja Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+package render
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/nanoninja/assert"
+)
+
+var (
+	_ Renderer = (*dotenvRenderer)(nil)
+	_ Renderer = Dotenv()
+)
+
+func TestDotenvRenderer(t *testing.T) {
+	t.Run("RendersStructsInUpperSnakeCase", func(t *testing.T) {
+		var w bytes.Buffer
+
+		err := Dotenv().Render(&w, newConfigServer())
+
+		assert.Nil(t, err)
+		assert.Equals(t, w.String(), ""+
+			"NAME=api\n"+
+			"PORT=8080\n"+
+			"HOSTS=a.local,b.local\n"+
+			"DATABASE_URL=postgres://db/app\n"+
+			"DATABASE_POOL_MAX_CONNS=10\n")
+	})
+
+	t.Run("QuotesValues", func(t *testing.T) {
+		var w bytes.Buffer
+		data := map[string]string{
+			"BARE":   "user@host:5432/db",
+			"QUERY":  "db?ssl=true",
+			"EMPTY":  "",
+			"SPACES": "hello world",
+			"DOLLAR": "pa$$word",
+			"SINGLE": "it's $HOME",
+			"LINES":  "a\nb",
+			"QUOTED": "it's\r\nfine",
+		}
+
+		err := Dotenv().Render(&w, data)
+
+		assert.Nil(t, err)
+		assert.Equals(t, w.String(), ""+
+			"BARE=user@host:5432/db\n"+
+			"DOLLAR='pa$$word'\n"+
+			"EMPTY=\n"+
+			"LINES='a\nb'\n"+
+			"QUERY='db?ssl=true'\n"+
+			"QUOTED=\"it's\r\nfine\"\n"+
+			`SINGLE="it's \$HOME"`+"\n"+
+			"SPACES='hello world'\n")
+	})
+
+	t.Run("WritesExportAndHeaderComment", func(t *testing.T) {
+		var w bytes.Buffer
+
+		err := NewDotenv(DotenvConfig{Export: true}).Render(&w, map[string]int{"PORT": 80}, Format(HeaderComment("Local settings\rEVIL=1")))
+
+		assert.Nil(t, err)
+		assert.Equals(t, w.String(), "# Local settings\n# EVIL=1\n\nexport PORT=80\n")
+	})
+
+	t.Run("AppliesLineEnding", func(t *testing.T) {
+		var w bytes.Buffer
+
+		err := Dotenv().Render(&w, map[string]int{"A": 1, "B": 2}, UseCRLF())
+
+		assert.Nil(t, err)
+		assert.Equals(t, w.String(), "A=1\r\nB=2\r\n")
+	})
+
+	t.Run("KeepsLineBreaksOfValues", func(t *testing.T) {
+		var w bytes.Buffer
+
+		err := Dotenv().Render(&w, map[string]string{"A": "x\ny", "B": "z"}, Format(Prefix("export ")), UseCRLF())
+
+		assert.Nil(t, err)
+		assert.Equals(t, w.String(), "export A='x\ny'\r\nexport B=z\r\n")
+	})
+
+	t.Run("RejectsInvalidNames", func(t *testing.T) {
+		for _, key := range []string{"my-key", "1ST", "a.b", "", "É"} {
+			var w bytes.Buffer
+
+			err := Dotenv().Render(&w, map[string]int{key: 1})
+
+			assert.ErrorIs(t, err, ErrInvalidKey)
+			assert.Equals(t, w.Len(), 0)
+		}
+	})
+
+	t.Run("RespectsContextCancellation", func(t *testing.T) {
+		var w bytes.Buffer
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		err := Dotenv().RenderContext(ctx, &w, map[string]int{"A": 1})
+
+		assert.ErrorIs(t, err, context.Canceled)
+	})
+}
@@ -3,7 +3,10 @@
 // license that can be found in the LICENSE file.
 package render
 
-import "errors"
+import (
+	"errors"
+	"strings"
+)
 
 // Package level errors that can be returned by Options validation
 var (
@@ -28,4 +31,78 @@ var (
 	// by the renderer. This might happen when the data type is incompatible
 	// with the chosen renderer.
 	ErrInvalidData = errors.New("invalid data for renderer")
+
+	// ErrMaxSizeExceeded indicates that the rendered content grew beyond
+	// the configured maximum size.
+	ErrMaxSizeExceeded = errors.New("maximum output size exceeded")
+
+	// ErrRangeNotSatisfiable indicates that none of the requested byte
+	// ranges overlap the content.
+	ErrRangeNotSatisfiable = errors.New("range not satisfiable")
+
+	// ErrUnsupportedCharset indicates that the charset declared by the
+	// content type cannot be produced by the renderer.
+	ErrUnsupportedCharset = errors.New("unsupported charset")
+
+	// ErrUnrepresentable indicates that the content holds a character that
+	// cannot be represented in the declared charset.
+	ErrUnrepresentable = errors.New("character not representable in charset")
+
+	// ErrInvalidMetric indicates that a metric family cannot be exposed,
+	// such as when its name or labels are invalid or duplicated.
+	ErrInvalidMetric = errors.New("invalid metric")
+
+	// ErrInvalidKey indicates that a key cannot be represented in the
+	// output format, such as a dotenv variable name with a dash.
+	ErrInvalidKey = errors.New("key not representable in format")
 )
+
+// joinErrors returns an error wrapping the non-nil errors of errs, or nil
+// when there are none. The result matches each of them with errors.Is and
+// errors.As.
+func joinErrors(errs ...error) error {
+	var joined multiError
+	for _, err := range errs {
+		if err != nil {
+			joined = append(joined, err)
+		}
+	}
+	switch len(joined) {
+	case 0:
+		return nil
+	case 1:
+		return joined[0]
+	}
+	return joined
+}
+
+// multiError is a list of errors reported together.
+type multiError []error
+
+func (m multiError) Error() string {
+	msgs := make([]string, len(m))
+	for i, err := range m {
+		msgs[i] = err.Error()
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// Is reports whether any of the errors matches target.
+func (m multiError) Is(target error) bool {
+	for _, err := range m {
+		if errors.Is(err, target) {
+			return true
+		}
+	}
+	return false
+}
+
+// As finds the first of the errors matching target.
+func (m multiError) As(target any) bool {
+	for _, err := range m {
+		if errors.As(err, target) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,284 @@
+// Copyright 2025 The Nanoninja Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+package render
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"html/template"
+	"io"
+	"mime"
+	"net/http"
+	"strings"
+)
+
+// FallbackConfig holds configuration for the FallbackRenderer.
+type FallbackConfig struct {
+	// OnError renders the error view when the primary renderer fails.
+	// It receives an ErrorData value as data. If nil, ErrorPage is used.
+	OnError Renderer
+
+	// Development exposes the error message through ErrorData.Detail.
+	// It should be disabled in production to avoid leaking internals.
+	Development bool
+
+	// Report is called with the primary renderer error before the error
+	// view is rendered. It can be used to log failures that would otherwise
+	// be hidden by a successful fallback.
+	Report func(ctx context.Context, err error)
+}
+
+// ErrorData is the data passed to the error view of a FallbackRenderer.
+type ErrorData struct {
+	// Err is the error returned by the primary renderer.
+	Err error
+
+	// Status is the HTTP status code suggested for the response.
+	Status int
+
+	// Title is a short, human-readable summary of the problem.
+	Title string
+
+	// Detail holds the error message in development mode only.
+	Detail string
+
+	// Data is the data that was passed to the primary renderer.
+	Data any
+}
+
+// FallbackRenderer renders content with a primary renderer and switches to
+// an error view when it fails. The primary output is buffered, so a failure
+// halfway through never reaches the client as a truncated page.
+type FallbackRenderer struct {
+	primary     Renderer                             // The renderer producing regular content
+	onError     Renderer                             // The renderer producing the error view
+	development bool                                 // Whether error details are exposed
+	report      func(ctx context.Context, err error) // Optional error reporting hook
+}
+
+// Fallback creates a FallbackRenderer rendering onError when primary fails.
+// If onError is nil, ErrorPage is used.
+//
+// Example:
+//
+//	page := render.Fallback(tmpl.HTML("page", tmpl.LoadHTML(src)), nil)
+//	page.Render(w, data, render.Name("index.html"))
+func Fallback(primary, onError Renderer) *FallbackRenderer {
+	return NewFallback(primary, FallbackConfig{OnError: onError})
+}
+
+// NewFallback creates a FallbackRenderer with custom configuration.
+func NewFallback(primary Renderer, c FallbackConfig) *FallbackRenderer {
+	onError := c.OnError
+	if onError == nil {
+		onError = ErrorPage()
+	}
+	return &FallbackRenderer{
+		primary:     primary,
+		onError:     onError,
+		development: c.Development,
+		report:      c.Report,
+	}
+}
+
+// Render implements fallback rendering using a background context.
+// See RenderContext for details on the rendering process.
+func (r *FallbackRenderer) Render(w io.Writer, data any, opts ...func(*Options)) error {
+	return r.RenderContext(context.Background(), w, data, opts...)
+}
+
+// RenderContext implements fallback rendering with context support.
+// The rendering process follows these steps:
+// 1. Renders the primary content into a pooled buffer
+// 2. On success, writes the buffered content to the provided writer
+// 3. On failure, discards the buffer and renders the error view instead
+//
+// The error view is rendered with its own options, holding the content type
+// and format options resolved by the primary renderer so that it can pick a
+// matching format. The other options of the call, such as template names,
+// are meant for the primary renderer and are not passed to the error view.
+// The view is responsible for the response status, which ErrorPage sets
+// from ErrorData.Status. Context errors and invalid options, wrapping
+// ErrInvalidParam, are returned as-is without rendering the error view.
+func (r *FallbackRenderer) RenderContext(ctx context.Context, w io.Writer, data any, opts ...func(*Options)) error {
+	if err := CheckContext(ctx); err != nil {
+		return err
+	}
+	buf := getBuffer()
+	defer putBuffer(buf)
+
+	var resolved *Options
+	err := r.primary.RenderContext(ctx, buf, data, append(opts[:len(opts):len(opts)], CaptureOptions(&resolved))...)
+	if err == nil {
+		_, err = w.Write(buf.Bytes())
+		return err
+	}
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) ||
+		errors.Is(err, ErrInvalidParam) {
+		return err
+	}
+	if r.report != nil {
+		r.report(ctx, err)
+	}
+	errData := ErrorData{
+		Err:    err,
+		Status: http.StatusInternalServerError,
+		Title:  http.StatusText(http.StatusInternalServerError),
+		Data:   data,
+	}
+	if r.development {
+		errData.Detail = err.Error()
+	}
+	var fallbackOpts []func(*Options)
+	if resolved != nil {
+		format := resolved.Format()
+		fallbackOpts = append(fallbackOpts, func(o *Options) { o.format = format.Clone() })
+		if contentType := resolved.ContentType(); contentType != "" {
+			fallbackOpts = append(fallbackOpts, Header(func(h HeaderOptions) {
+				h.Set("Content-Type", contentType)
+			}))
+		}
+	}
+	if ferr := r.onError.RenderContext(ctx, w, errData, fallbackOpts...); ferr != nil {
+		return fmt.Errorf("fallback render: %w (primary error: %v)", ferr, err)
+	}
+	return nil
+}
+
+// errorPageRenderer renders ErrorData in a format matching the content type.
+type errorPageRenderer struct{}
+
+// ErrorPage creates a renderer for ErrorData values that picks its output
+// format from the resolved content type:
+// - HTML content types produce a minimal HTML error page
+// - JSON content types produce an RFC 7807 problem document, with the
+// application/problem+json content type
+// - Any other content type produces plain text
+//
+// When the writer is an http.ResponseWriter, the content type and the
+// status of the error are written to the response before the view.
+//
+// Errors and other values are accepted as well and reported as internal
+// server errors.
+func ErrorPage() Renderer {
+	return &errorPageRenderer{}
+}
+
+// Render writes the error view using a background context.
+func (r *errorPageRenderer) Render(w io.Writer, data any, opts ...func(*Options)) error {
+	return r.RenderContext(context.Background(), w, data, opts...)
+}
+
+// RenderContext writes the error view with context support.
+func (r *errorPageRenderer) RenderContext(ctx context.Context, w io.Writer, data any, opts ...func(*Options)) error {
+	if err := CheckContext(ctx); err != nil {
+		return err
+	}
+	options := NewOptions().
+		Use(MimeTextPlain()).
+		Use(ContextDefaults(ctx)).
+		Use(opts...)
+
+	if err := options.Err(); err != nil {
+		return err
+	}
+	useProblemType(options)
+
+	errData := toErrorData(data)
+	writeStatus(w, options, errData.Status, "Content-Type")
+
+	out, err := OutputWriter(w, options)
+	if err != nil {
+		return err
+	}
+	if err := writeErrorView(out, errData, options); err != nil {
+		return err
+	}
+	return out.Close()
+}
+
+// writeErrorView writes the error view matching the content type of o.
+func writeErrorView(w io.Writer, errData ErrorData, o *Options) error {
+	mediatype, _, _ := mime.ParseMediaType(o.ContentType())
+
+	switch {
+	case strings.Contains(mediatype, "html"):
+		return errorPageTemplate.Execute(w, errData)
+	case isJSONMediaType(mediatype):
+		return json.NewEncoder(w).Encode(problemDetails{
+			Type:   "about:blank",
+			Title:  errData.Title,
+			Status: errData.Status,
+			Detail: errData.Detail,
+		})
+	default:
+		text := fmt.Sprintf("%d %s", errData.Status, errData.Title)
+		if errData.Detail != "" {
+			text += ": " + errData.Detail
+		}
+		_, err := io.WriteString(w, text+"\n")
+		return err
+	}
+}
+
+// useProblemType replaces a JSON content type of o with the media type of
+// problem documents.
+func useProblemType(o *Options) {
+	mediatype, _, _ := mime.ParseMediaType(o.ContentType())
+	if isJSONMediaType(mediatype) && mediatype != "application/problem+json" {
+		o.Use(Mime("application/problem+json"))
+	}
+}
+
+// isJSONMediaType reports whether mediatype is JSON or a JSON based format.
+func isJSONMediaType(mediatype string) bool {
+	return mediatype == "application/json" || strings.HasSuffix(mediatype, "+json")
+}
+
+// problemDetails is an RFC 7807 problem document.
+type problemDetails struct {
+	Type   string `json:"type"`
+	Title  string `json:"title"`
+	Status int    `json:"status"`
+	Detail string `json:"detail,omitempty"`
+}
+
+// errorPageTemplate is the HTML error view used by ErrorPage.
+var errorPageTemplate = template.Must(template.New("error").Parse(`<!DOCTYPE html>
+<html>
+<head><meta charset="utf-8"><title>{{ .Status }} {{ .Title }}</title></head>
+<body>
+<h1>{{ .Status }} {{ .Title }}</h1>
+{{- if .Detail }}
+<pre>{{ .Detail }}</pre>
+{{- end }}
+</body>
+</html>
+`))
+
+// toErrorData normalizes the data given to the error view.
+func toErrorData(data any) ErrorData {
+	var errData ErrorData
+	switch v := data.(type) {
+	case ErrorData:
+		errData = v
+	case *ErrorData:
+		if v != nil {
+			errData = *v
+		}
+	case error:
+		errData.Err = v
+	default:
+		errData.Data = v
+	}
+	if errData.Status == 0 {
+		errData.Status = http.StatusInternalServerError
+	}
+	if errData.Title == "" {
+		errData.Title = http.StatusText(errData.Status)
+	}
+	return errData
+}
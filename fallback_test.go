@@ -0,0 +1,228 @@
+// Copyright 2025 The Nanoninja Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+package render
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/nanoninja/assert"
+)
+
+var (
+	_ Renderer = Fallback(nil, nil)
+	_ Renderer = ErrorPage()
+)
+
+// partialRenderer writes some content before failing.
+type partialRenderer struct {
+	content string
+	err     error
+	mime    func(*Options)
+}
+
+func (r *partialRenderer) Render(w io.Writer, data any, opts ...func(*Options)) error {
+	return r.RenderContext(context.Background(), w, data, opts...)
+}
+
+func (r *partialRenderer) RenderContext(_ context.Context, w io.Writer, _ any, opts ...func(*Options)) error {
+	NewOptions().Use(r.mime).Use(opts...)
+
+	if _, err := io.WriteString(w, r.content); err != nil {
+		return err
+	}
+	return r.err
+}
+
+func TestFallbackRenderer(t *testing.T) {
+	t.Run("WritesPrimaryContentOnSuccess", func(t *testing.T) {
+		var w bytes.Buffer
+
+		err := Fallback(Text(), nil).Render(&w, "hello")
+
+		assert.Nil(t, err)
+		assert.Equals(t, w.String(), "hello")
+	})
+
+	t.Run("DiscardsPartialOutputOnError", func(t *testing.T) {
+		var w bytes.Buffer
+
+		primary := &partialRenderer{content: "<h1>half", err: errors.New("boom"), mime: MimeTextHTML()}
+
+		err := Fallback(primary, nil).Render(&w, nil)
+
+		assert.Nil(t, err)
+		assert.False(t, bytes.Contains(w.Bytes(), []byte("<h1>half")))
+		assert.StringContains(t, w.String(), "<h1>500 Internal Server Error</h1>")
+		assert.False(t, bytes.Contains(w.Bytes(), []byte("boom")))
+	})
+
+	t.Run("PassesErrorToErrorView", func(t *testing.T) {
+		var w bytes.Buffer
+		var received ErrorData
+
+		expectedErr := errors.New("boom")
+		primary := &partialRenderer{err: expectedErr, mime: MimeTextPlain()}
+		onError := RendererFunc(func(_ context.Context, w io.Writer, data any, _ ...func(*Options)) error {
+			received = data.(ErrorData)
+			_, err := io.WriteString(w, "oops")
+			return err
+		})
+
+		err := Fallback(primary, onError).Render(&w, "original")
+
+		assert.Nil(t, err)
+		assert.Equals(t, w.String(), "oops")
+		assert.ErrorIs(t, received.Err, expectedErr)
+		assert.Equals(t, received.Status, 500)
+		assert.Equals(t, received.Data, any("original"))
+		assert.Equals(t, received.Detail, "")
+	})
+
+	t.Run("ExposesDetailsInDevelopmentMode", func(t *testing.T) {
+		var w bytes.Buffer
+
+		primary := &partialRenderer{err: errors.New("boom"), mime: MimeTextPlain()}
+		renderer := NewFallback(primary, FallbackConfig{Development: true})
+
+		err := renderer.Render(&w, nil)
+
+		assert.Nil(t, err)
+		assert.Equals(t, w.String(), "500 Internal Server Error: boom\n")
+	})
+
+	t.Run("UsesPrimaryContentType", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+
+		primary := &partialRenderer{err: errors.New("boom"), mime: MimeJSON()}
+
+		err := Fallback(primary, nil).Render(rec, nil)
+
+		assert.Nil(t, err)
+		assert.Equals(t, rec.Code, 500)
+		assert.Equals(t, rec.Header().Get("Content-Type"), "application/problem+json")
+		assert.Equals(t, rec.Body.String(), `{"type":"about:blank","title":"Internal Server Error","status":500}`+"\n")
+	})
+
+	t.Run("KeepsCallOptionsForPrimaryRenderer", func(t *testing.T) {
+		var name string
+
+		primary := &partialRenderer{err: errors.New("boom"), mime: MimeTextPlain()}
+		onError := RendererFunc(func(_ context.Context, _ io.Writer, _ any, opts ...func(*Options)) error {
+			options := NewOptions().Use(opts...)
+			name = options.Name()
+			assert.Equals(t, options.Format().Prefix(), "> ")
+			return nil
+		})
+
+		err := Fallback(primary, onError).Render(io.Discard, nil, Name("page.html"), Format(Prefix("> ")))
+
+		assert.Nil(t, err)
+		assert.Equals(t, name, "")
+	})
+
+	t.Run("ReportsPrimaryError", func(t *testing.T) {
+		var reported error
+
+		expectedErr := errors.New("boom")
+		primary := &partialRenderer{err: expectedErr, mime: MimeTextPlain()}
+		renderer := NewFallback(primary, FallbackConfig{
+			Report: func(_ context.Context, err error) { reported = err },
+		})
+
+		err := renderer.Render(io.Discard, nil)
+
+		assert.Nil(t, err)
+		assert.ErrorIs(t, reported, expectedErr)
+	})
+
+	t.Run("ReturnsErrorWhenErrorViewFails", func(t *testing.T) {
+		primary := &partialRenderer{err: errors.New("boom"), mime: MimeTextPlain()}
+		onError := &mockRenderer{err: errors.New("view failed")}
+
+		err := Fallback(primary, onError).Render(io.Discard, nil)
+
+		assert.NotNil(t, err)
+		assert.StringContains(t, err.Error(), "view failed")
+		assert.StringContains(t, err.Error(), "boom")
+	})
+
+	t.Run("ReturnsContextErrorsAsIs", func(t *testing.T) {
+		var w bytes.Buffer
+
+		primary := &partialRenderer{content: "partial", err: context.DeadlineExceeded, mime: MimeTextPlain()}
+
+		err := Fallback(primary, nil).Render(&w, nil)
+
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+		assert.Equals(t, w.String(), "")
+	})
+
+	t.Run("ReturnsOptionErrorsAsIs", func(t *testing.T) {
+		var w bytes.Buffer
+
+		err := Fallback(Text(), nil).Render(&w, "hello", Strict(), Param("unknown", "x"))
+
+		assert.ErrorIs(t, err, ErrInvalidParam)
+		assert.Equals(t, w.String(), "")
+	})
+
+	t.Run("RespectContextCancellation", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		err := Fallback(Text(), nil).RenderContext(ctx, io.Discard, "hello")
+
+		assert.ErrorIs(t, err, context.Canceled)
+	})
+}
+
+func TestErrorPage(t *testing.T) {
+	t.Run("RendersPlainTextByDefault", func(t *testing.T) {
+		var w bytes.Buffer
+
+		err := ErrorPage().Render(&w, errors.New("boom"))
+
+		assert.Nil(t, err)
+		assert.Equals(t, w.String(), "500 Internal Server Error\n")
+	})
+
+	t.Run("RendersHTMLPage", func(t *testing.T) {
+		var w bytes.Buffer
+
+		data := ErrorData{Status: 404, Detail: "<missing>"}
+
+		err := ErrorPage().Render(&w, data, MimeTextHTML())
+
+		assert.Nil(t, err)
+		assert.StringContains(t, w.String(), "<title>404 Not Found</title>")
+		assert.StringContains(t, w.String(), "<pre>&lt;missing&gt;</pre>")
+	})
+
+	t.Run("RendersProblemJSON", func(t *testing.T) {
+		var w bytes.Buffer
+
+		data := &ErrorData{Status: 503, Detail: "maintenance"}
+
+		err := ErrorPage().Render(&w, data, Mime("application/problem+json"))
+
+		assert.Nil(t, err)
+		assert.Equals(t, w.String(), `{"type":"about:blank","title":"Service Unavailable","status":503,"detail":"maintenance"}`+"\n")
+	})
+
+	t.Run("WritesStatusAndProblemContentType", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+
+		err := ErrorPage().Render(rec, ErrorData{Status: 404}, MimeJSON())
+
+		assert.Nil(t, err)
+		assert.Equals(t, rec.Code, 404)
+		assert.Equals(t, rec.Header().Get("Content-Type"), "application/problem+json")
+		assert.Equals(t, rec.Body.String(), `{"type":"about:blank","title":"Not Found","status":404}`+"\n")
+	})
+}
@@ -0,0 +1,226 @@
+// Copyright 2025 The Nanoninja Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+package render
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/url"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// FilePart is a file sent as a part of a multipart/form-data body. It is
+// used as the value of a field, such as an entry of a map or a field of a
+// struct rendered by Multipart.
+//
+// Example:
+//
+//	f, _ := os.Open("avatar.png")
+//	defer f.Close()
+//	render.Multipart().Render(w, map[string]any{
+//	    "name":   "gopher",
+//	    "avatar": render.FilePart{Filename: "avatar.png", ContentType: "image/png", Reader: f},
+//	})
+type FilePart struct {
+	// Filename is the name of the file sent to the server.
+	Filename string
+
+	// ContentType is the content type of the file. The default is
+	// application/octet-stream.
+	ContentType string
+
+	// Reader provides the content of the file. A nil reader sends an
+	// empty file.
+	Reader io.Reader
+}
+
+var filePartType = reflect.TypeOf(FilePart{})
+
+// formRenderer implements the rendering of application/x-www-form-urlencoded
+// bodies.
+type formRenderer struct{}
+
+// Form creates a new renderer writing data as an
+// application/x-www-form-urlencoded body, such as the body of an HTML form
+// submission.
+//
+// Example:
+//
+//	var body bytes.Buffer
+//	render.Form().Render(&body, url.Values{"grant_type": {"client_credentials"}})
+//	http.Post(tokenURL, "application/x-www-form-urlencoded", &body)
+func Form() Renderer {
+	return &formRenderer{}
+}
+
+// Render writes data as a form body using a background context.
+// Data must be one of:
+// - url.Values or a map: fields sorted by key
+// - struct: one field per exported struct field, in declaration order,
+// named by the "form" struct tag when set, skipped when the tag is "-" and
+// when empty with the "omitempty" tag option
+// - []KeyValue: fields in the order of the slice
+// Slices of scalars are written as repeated fields, such as tag=a&tag=b.
+// Nested maps and structs are named with brackets, such as
+// address[city]=Paris, and slices of them with indexes, such as
+// items[0][id]=1.
+func (r *formRenderer) Render(w io.Writer, data any, opts ...func(*Options)) error {
+	return r.RenderContext(context.Background(), w, data, opts...)
+}
+
+// RenderContext writes data as a form body with context support.
+// Names and values are escaped with url.QueryEscape. Values implementing
+// encoding.TextMarshaler, error or fmt.Stringer are written as text, and
+// nil values as empty values. File parts are not supported and return an
+// error wrapping ErrInvalidData.
+// The body is written as-is, ignoring the format options. The content
+// type is application/x-www-form-urlencoded.
+func (r *formRenderer) RenderContext(ctx context.Context, w io.Writer, data any, opts ...func(*Options)) error {
+	if err := CheckContext(ctx); err != nil {
+		return err
+	}
+	options := NewOptions().
+		Use(MimeForm()).
+		Use(ContextDefaults(ctx)).
+		Use(opts...)
+
+	if err := options.Err(); err != nil {
+		return err
+	}
+
+	fields, err := formFields(data)
+	if err != nil {
+		return err
+	}
+
+	var b strings.Builder
+	for i, field := range fields {
+		if field.file != nil {
+			return fmt.Errorf("%w: file part %q in url-encoded form", ErrInvalidData, field.name)
+		}
+		if i > 0 {
+			b.WriteByte('&')
+		}
+		b.WriteString(url.QueryEscape(field.name) + "=" + url.QueryEscape(field.value))
+	}
+	_, err = io.WriteString(w, b.String())
+	return err
+}
+
+// formField is a named value of a form, holding either text or a file.
+type formField struct {
+	name  string
+	value string
+	file  *FilePart
+}
+
+// formFields flattens data into the fields of a form. Data must be a map,
+// a struct or a []KeyValue.
+func formFields(data any) ([]formField, error) {
+	v := indirectValue(reflect.ValueOf(data))
+	if !v.IsValid() || v.Type() == filePartType ||
+		v.Type() != keyValuesType && v.Kind() != reflect.Map && v.Kind() != reflect.Struct {
+		return nil, ErrInvalidData
+	}
+	if v.Kind() == reflect.Struct && v.Type().Implements(textMarshalerType) {
+		return nil, ErrInvalidData
+	}
+	return appendFormFields(nil, make(map[valueRef]bool), "", reflect.ValueOf(data))
+}
+
+// appendFormFields appends the fields of v with names starting with
+// prefix, flattening nested values. Values referring to themselves return
+// an error wrapping ErrInvalidData.
+func appendFormFields(fields []formField, visiting map[valueRef]bool, prefix string, v reflect.Value) ([]formField, error) {
+	if ref, ok := valueReference(v); ok {
+		if visiting[ref] {
+			return nil, fmt.Errorf("%w: cycle at field %q", ErrInvalidData, prefix)
+		}
+		visiting[ref] = true
+		defer delete(visiting, ref)
+	}
+	v = indirectValue(v)
+	if isFormValue(v) {
+		return appendFormValue(fields, prefix, v), nil
+	}
+	var err error
+	switch {
+	case v.Type() == keyValuesType:
+		for _, kv := range v.Interface().([]KeyValue) {
+			if fields, err = appendFormFields(fields, visiting, formJoin(prefix, kv.Key), reflect.ValueOf(kv.Value)); err != nil {
+				return nil, err
+			}
+		}
+	case v.Kind() == reflect.Map:
+		for _, key := range sortedMapKeys(v) {
+			if fields, err = appendFormFields(fields, visiting, formJoin(prefix, fmt.Sprint(key.Interface())), v.MapIndex(key)); err != nil {
+				return nil, err
+			}
+		}
+	case v.Kind() == reflect.Struct:
+		typ := v.Type()
+		for i := 0; i < typ.NumField(); i++ {
+			field := typ.Field(i)
+			if !field.IsExported() {
+				continue
+			}
+			name, omitEmpty, skip := fieldTag(field, "form")
+			if skip || omitEmpty && v.Field(i).IsZero() {
+				continue
+			}
+			if name == "" {
+				name = field.Name
+			}
+			if fields, err = appendFormFields(fields, visiting, formJoin(prefix, name), v.Field(i)); err != nil {
+				return nil, err
+			}
+		}
+	case v.Kind() == reflect.Slice || v.Kind() == reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			item := indirectValue(v.Index(i))
+			if isFormValue(item) {
+				fields = appendFormValue(fields, prefix, item)
+				continue
+			}
+			if fields, err = appendFormFields(fields, visiting, formJoin(prefix, strconv.Itoa(i)), v.Index(i)); err != nil {
+				return nil, err
+			}
+		}
+	default:
+		return nil, fmt.Errorf("%w: %s field %q", ErrInvalidData, v.Kind(), prefix)
+	}
+	return fields, nil
+}
+
+// appendFormValue appends the scalar value or file part v named name.
+func appendFormValue(fields []formField, name string, v reflect.Value) []formField {
+	if v.IsValid() && v.Type() == filePartType {
+		file := v.Interface().(FilePart)
+		return append(fields, formField{name: name, file: &file})
+	}
+	return append(fields, formField{name: name, value: textValue(v)})
+}
+
+// isFormValue reports whether v is written as a single field.
+func isFormValue(v reflect.Value) bool {
+	if !v.IsValid() || v.Type() == filePartType {
+		return true
+	}
+	switch v.Kind() {
+	case reflect.Func, reflect.Chan, reflect.UnsafePointer:
+		return false
+	}
+	return isScalarValue(v)
+}
+
+// formJoin joins a name to the prefix of its parent with brackets.
+func formJoin(prefix, name string) string {
+	if prefix == "" {
+		return name
+	}
+	return prefix + "[" + name + "]"
+}
@@ -0,0 +1,153 @@
+// Copyright 2025 The Nanoninja Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+package render
+
+import (
+	"bytes"
+	"context"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/nanoninja/assert"
+)
+
+var (
+	_ Renderer = (*formRenderer)(nil)
+	_ Renderer = Form()
+)
+
+// formSignup is a struct form with tags.
+type formSignup struct {
+	Email    string    `form:"email"`
+	Name     string    `form:"name,omitempty"`
+	Tags     []string  `form:"tag"`
+	Birthday time.Time `form:"birthday"`
+	Password string    `form:"-"`
+	Address  struct {
+		City string `form:"city"`
+		Zip  string `form:"zip"`
+	} `form:"address"`
+	Newsletter bool
+	internal   string
+}
+
+func TestFormRenderer(t *testing.T) {
+	t.Run("RendersURLValues", func(t *testing.T) {
+		var w bytes.Buffer
+
+		err := Form().Render(&w, url.Values{"scope": {"read write"}, "grant_type": {"client_credentials"}})
+
+		assert.Nil(t, err)
+		assert.Equals(t, w.String(), "grant_type=client_credentials&scope=read+write")
+	})
+
+	t.Run("RendersStructsWithTags", func(t *testing.T) {
+		var w bytes.Buffer
+		data := formSignup{
+			Email:    "gopher@example.com",
+			Tags:     []string{"go", "c&c"},
+			Birthday: time.Date(2009, 11, 10, 0, 0, 0, 0, time.UTC),
+			Password: "secret",
+		}
+		data.Address.City = "Paris"
+		data.Address.Zip = "75001"
+
+		err := Form().Render(&w, &data)
+
+		assert.Nil(t, err)
+		assert.Equals(t, w.String(), "email=gopher%40example.com&tag=go&tag=c%26c"+
+			"&birthday=2009-11-10T00%3A00%3A00Z&address%5Bcity%5D=Paris&address%5Bzip%5D=75001&Newsletter=false")
+	})
+
+	t.Run("RendersOrderedKeyValues", func(t *testing.T) {
+		var w bytes.Buffer
+
+		err := Form().Render(&w, KeyValues("z", 1, "a", nil))
+
+		assert.Nil(t, err)
+		assert.Equals(t, w.String(), "z=1&a=")
+	})
+
+	t.Run("IndexesSlicesOfRecords", func(t *testing.T) {
+		var w bytes.Buffer
+		data := map[string]any{"items": []map[string]int{{"id": 1}, {"id": 2}}}
+
+		err := Form().Render(&w, data)
+
+		assert.Nil(t, err)
+		values, err := url.ParseQuery(w.String())
+		assert.Nil(t, err)
+		assert.Equals(t, values, url.Values{"items[0][id]": {"1"}, "items[1][id]": {"2"}})
+	})
+
+	t.Run("RejectsFileParts", func(t *testing.T) {
+		var w bytes.Buffer
+
+		err := Form().Render(&w, map[string]any{"file": FilePart{Filename: "a.txt"}})
+
+		assert.ErrorIs(t, err, ErrInvalidData)
+		assert.Equals(t, w.Len(), 0)
+	})
+
+	t.Run("RejectsInvalidData", func(t *testing.T) {
+		for _, data := range []any{nil, "text", 42, []string{"a"}, time.Now(), map[string]any{"f": func() {}}} {
+			var w bytes.Buffer
+
+			err := Form().Render(&w, data)
+
+			assert.ErrorIs(t, err, ErrInvalidData)
+			assert.Equals(t, w.Len(), 0)
+		}
+	})
+
+	t.Run("RejectsCycles", func(t *testing.T) {
+		renderers := map[string]Renderer{"Form": Form(), "Multipart": Multipart()}
+
+		for name, r := range renderers {
+			for kind, data := range newCyclicData() {
+				t.Run(name+kind, func(t *testing.T) {
+					var w bytes.Buffer
+
+					err := r.Render(&w, data)
+
+					assert.ErrorIs(t, err, ErrInvalidData)
+					assert.Equals(t, w.Len(), 0)
+				})
+			}
+		}
+	})
+
+	t.Run("IgnoresFormatOptions", func(t *testing.T) {
+		var w bytes.Buffer
+
+		err := Form().Render(&w, map[string]string{"a": "b"}, Format(Prefix("> ")), UseCRLF())
+
+		assert.Nil(t, err)
+		assert.Equals(t, w.String(), "a=b")
+	})
+
+	t.Run("SetsContentType", func(t *testing.T) {
+		var (
+			w       bytes.Buffer
+			options *Options
+		)
+
+		err := Form().Render(&w, map[string]string{"a": "b"}, CaptureOptions(&options))
+
+		assert.Nil(t, err)
+		assert.Equals(t, options.ContentType(), "application/x-www-form-urlencoded")
+	})
+
+	t.Run("ReturnsContextError", func(t *testing.T) {
+		var w bytes.Buffer
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		err := Form().RenderContext(ctx, &w, map[string]string{"a": "b"})
+
+		assert.ErrorIs(t, err, context.Canceled)
+		assert.Equals(t, w.Len(), 0)
+	})
+}
@@ -3,14 +3,44 @@
 // license that can be found in the LICENSE file.
 package render
 
+import (
+	"io"
+)
+
 // FormatOptions defines common formatting settings for all renderers.
 // It provides basic text formatting capabilities that can be used
 // across different types of output (JSON, XML, Text, etc.).
+//
+// Built-in renderers honor the options as follows:
+//   - prefix is written at the start of every line of the output, including
+//     the XML declaration and JSONP padding. It overrides the Prefix of
+//     JSONConfig and XMLConfig
+//   - lineEnding replaces every line break of the output, whether written
+//     as "\n" or "\r\n". When empty, line breaks are written unchanged
+//   - neither applies to line breaks within quoted values, as written by
+//     CSV, Prometheus and Dotenv, while the data of Text and templates is
+//     formatted like the rest of their output
+//   - indent is the indentation unit of nested formats (JSON, XML) when
+//     pretty is set. It overrides the Indent of JSONConfig and XMLConfig,
+//     and is ignored by flat formats (Text, CSV, templates)
+//   - pretty indents nested formats and ends Text output with a line
+//     break. It has no effect on CSV and templates
+//
+// Binary content is written as-is and ignores all formatting options.
+// Custom renderers get the same prefix and line ending handling by
+// writing through OutputWriter.
 type FormatOptions struct {
 	// prefix is added at the beginning of each line.
 	// It can be used for comments, indentation, or any line-starting content.
 	prefix string
 
+	// comment is the marker set with Comment, which also sets prefix.
+	comment string
+
+	// header is the text of the comment written at the top of
+	// configuration files.
+	header string
+
 	// lineEnding specifies the character(s) used for line termination.
 	// Common values are "\n" (Unix) or "\r\n" (Windows).
 	// If empty, the renderer will use its default line ending.
@@ -32,6 +62,7 @@ type FormatOptions struct {
 // Clone creates a deep copy of FormatOptions.
 // It returns a new FormatOptions instance with all fields copied from the original:
 // - prefix for line starting content
+// - comment and header for comments
 // - lineEnding for line termination characters
 // - indent for indentation string
 // - pretty flag for human-readable output
@@ -40,6 +71,8 @@ type FormatOptions struct {
 func (o FormatOptions) Clone() FormatOptions {
 	return FormatOptions{
 		prefix:     o.prefix,
+		comment:    o.comment,
+		header:     o.header,
 		lineEnding: o.lineEnding,
 		indent:     o.indent,
 		pretty:     o.pretty,
@@ -65,6 +98,16 @@ func (o FormatOptions) Prefix() string {
 	return o.prefix
 }
 
+// Comment returns the comment marker set with Comment.
+func (o FormatOptions) Comment() string {
+	return o.comment
+}
+
+// HeaderComment returns the text written at the top of configuration files.
+func (o FormatOptions) HeaderComment() string {
+	return o.header
+}
+
 // Pretty returns whether pretty formatting is enabled.
 func (o FormatOptions) Pretty() bool {
 	return o.pretty
@@ -87,9 +130,28 @@ func Format(formatters ...func(*FormatOptions)) func(*Options) {
 	}
 }
 
-// Comment adds a comment marker to each line.
+// Comment adds a comment marker to each line. Configuration renderers
+// write it before the lines of the header comment only.
 func Comment(marker string) func(*FormatOptions) {
-	return Prefix(marker + " ")
+	return func(f *FormatOptions) {
+		f.prefix = marker + " "
+		f.comment = marker
+	}
+}
+
+// HeaderComment sets a comment written at the top of configuration files
+// by the INI, Dotenv and Properties renderers. Each line of the text
+// starts with the marker set with Comment, or the default marker of the
+// renderer, and the entries are not commented out.
+//
+// Example:
+//
+//	render.Properties().Render(w, cfg, render.Format(render.Comment("!"), render.HeaderComment("Generated")))
+//	// ! Generated
+//	//
+//	// db.host=...
+func HeaderComment(text string) func(*FormatOptions) {
+	return func(f *FormatOptions) { f.header = text }
 }
 
 // Pretty enables pretty printing with default settings.
@@ -109,7 +171,10 @@ func LineEnding(ending string) func(*FormatOptions) {
 
 // Prefix sets the prefix string for formatted output.
 func Prefix(prefix string) func(*FormatOptions) {
-	return func(f *FormatOptions) { f.prefix = prefix }
+	return func(f *FormatOptions) {
+		f.prefix = prefix
+		f.comment = ""
+	}
 }
 
 // Textf provides a convenient way to set text format arguments.
@@ -138,3 +203,188 @@ func Textf(args ...any) func(*Options) {
 func UseCRLF() func(*Options) {
 	return Format(LineEnding("\r\n"))
 }
+
+// LineWriter returns a writer applying the prefix and line ending of f to
+// the content written to it. Line breaks are recognized as "\n" or "\r\n",
+// and the prefix is written when the first byte of each line is, so that
+// a trailing line break is not followed by a dangling prefix.
+// Renderers quoting or escaping their data, such as CSV, Prometheus and
+// Dotenv, write the line breaks within quoted values unchanged and without
+// prefix, so that the data reads back as it was given. Renderers writing
+// their data as-is, such as Text and templates, cannot tell its line
+// breaks apart and apply the format options to all of them.
+// The writer must be closed to flush a pending carriage return; closing it
+// does not close w. When f sets neither a prefix nor a line ending, the
+// content is written unchanged.
+func LineWriter(w io.Writer, f FormatOptions) io.WriteCloser {
+	if f.prefix == "" && f.lineEnding == "" {
+		return nopWriteCloser{w}
+	}
+	return &lineWriter{
+		w:          w,
+		prefix:     f.prefix,
+		lineEnding: f.lineEnding,
+		lineStart:  true,
+	}
+}
+
+// lineWriter applies a prefix and line ending to each line.
+type lineWriter struct {
+	w          io.Writer
+	prefix     string
+	lineEnding string // Replacement of line breaks, or empty to keep them
+	lineStart  bool   // Whether the next byte starts a line
+	pendingCR  bool   // Whether the previous write ended with a carriage return
+	buf        []byte
+}
+
+func (l *lineWriter) Write(p []byte) (int, error) {
+	l.buf = l.buf[:0]
+	for _, c := range p {
+		if l.pendingCR {
+			l.pendingCR = false
+			if c == '\n' {
+				l.newline("\r\n")
+				continue
+			}
+			l.text('\r')
+		}
+		switch c {
+		case '\r':
+			l.pendingCR = true
+		case '\n':
+			l.newline("\n")
+		default:
+			l.text(c)
+		}
+	}
+	if _, err := l.w.Write(l.buf); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+// writeData writes p as data, leaving its line breaks unchanged, even the
+// first one: they do not start new lines of the output. The prefix is still
+// written when p starts a line.
+func (l *lineWriter) writeData(p []byte) (int, error) {
+	l.buf = l.buf[:0]
+	if l.pendingCR {
+		l.pendingCR = false
+		l.text('\r')
+	}
+	if len(p) > 0 {
+		if l.lineStart {
+			l.buf = append(l.buf, l.prefix...)
+			l.lineStart = false
+		}
+		l.buf = append(l.buf, p...)
+	}
+	if _, err := l.w.Write(l.buf); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+// Close writes a pending carriage return. It does not close the
+// underlying writer.
+func (l *lineWriter) Close() error {
+	if !l.pendingCR {
+		return nil
+	}
+	l.pendingCR = false
+	l.buf = l.buf[:0]
+	l.text('\r')
+	_, err := l.w.Write(l.buf)
+	return err
+}
+
+// text appends a byte of line content, preceded by the prefix at the
+// start of a line.
+func (l *lineWriter) text(c byte) {
+	if l.lineStart {
+		l.buf = append(l.buf, l.prefix...)
+		l.lineStart = false
+	}
+	l.buf = append(l.buf, c)
+}
+
+// newline appends a line break, written as the configured line ending.
+// Empty lines get the prefix trimmed of trailing spaces, so that comment
+// markers such as "# " do not leave trailing whitespace.
+func (l *lineWriter) newline(original string) {
+	if l.lineStart {
+		l.buf = append(l.buf, trimRightSpace(l.prefix)...)
+	}
+	if l.lineEnding != "" {
+		original = l.lineEnding
+	}
+	l.buf = append(l.buf, original...)
+	l.lineStart = true
+}
+
+// trimRightSpace removes trailing spaces and tabs from s.
+func trimRightSpace(s string) string {
+	for len(s) > 0 && (s[len(s)-1] == ' ' || s[len(s)-1] == '\t') {
+		s = s[:len(s)-1]
+	}
+	return s
+}
+
+// OutputWriter returns the writer through which renderers write their
+// output once options are resolved. It applies, in order:
+//  1. The prefix and line ending of the format options, see LineWriter
+//  2. The charset declared by the content type, see Transcoder
+//
+// The writer must be closed once rendering succeeds to flush pending
+// content; closing it does not close w.
+//
+// Example:
+//
+//	out, err := render.OutputWriter(w, options)
+//	if err != nil {
+//	    return err
+//	}
+//	if _, err := io.WriteString(out, content); err != nil {
+//	    return err
+//	}
+//	return out.Close()
+func OutputWriter(w io.Writer, o *Options) (io.WriteCloser, error) {
+	tw, err := Transcoder(w, o)
+	if err != nil {
+		return nil, err
+	}
+	lw := LineWriter(tw, o.format)
+	if _, ok := lw.(nopWriteCloser); ok {
+		return tw, nil
+	}
+	return &outputWriter{Writer: lw, closers: []io.Closer{lw, tw}}, nil
+}
+
+// outputWriter closes its stages in order, from the head to the tail.
+type outputWriter struct {
+	io.Writer
+	closers []io.Closer
+}
+
+func (o *outputWriter) writeData(p []byte) (int, error) {
+	return writeData(o.Writer, p)
+}
+
+// writeData writes p to w as data of the output, whose line breaks are
+// written unchanged when w is returned by OutputWriter or LineWriter.
+func writeData(w io.Writer, p []byte) (int, error) {
+	if dw, ok := w.(interface{ writeData([]byte) (int, error) }); ok {
+		return dw.writeData(p)
+	}
+	return w.Write(p)
+}
+
+func (o *outputWriter) Close() error {
+	for _, c := range o.closers {
+		if err := c.Close(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -4,6 +4,8 @@
 package render
 
 import (
+	"bytes"
+	"io"
 	"testing"
 
 	"github.com/nanoninja/assert"
@@ -145,3 +147,109 @@ func TestFormatOptions(t *testing.T) {
 		assert.Equals(t, opts1.format.LineEnding(), opts2.format.LineEnding())
 	})
 }
+
+func TestLineWriter(t *testing.T) {
+	tests := []struct {
+		name     string
+		format   FormatOptions
+		writes   []string
+		expected string
+	}{
+		{
+			name:     "WritesUnchangedWithoutOptions",
+			writes:   []string{"a\r\nb\n"},
+			expected: "a\r\nb\n",
+		},
+		{
+			name:     "PrefixesEveryLine",
+			format:   FormatOptions{prefix: "> "},
+			writes:   []string{"a\nb\n"},
+			expected: "> a\n> b\n",
+		},
+		{
+			name:     "TrimsPrefixOnEmptyLines",
+			format:   FormatOptions{prefix: "# "},
+			writes:   []string{"a\n\nb"},
+			expected: "# a\n#\n# b",
+		},
+		{
+			name:     "ReplacesLineEndings",
+			format:   FormatOptions{lineEnding: "\r\n"},
+			writes:   []string{"a\nb\r\nc"},
+			expected: "a\r\nb\r\nc",
+		},
+		{
+			name:     "NormalizesToLF",
+			format:   FormatOptions{lineEnding: "\n"},
+			writes:   []string{"a\r\nb\rc"},
+			expected: "a\nb\rc",
+		},
+		{
+			name:     "HandlesLineBreaksAcrossWrites",
+			format:   FormatOptions{prefix: "|", lineEnding: "\n"},
+			writes:   []string{"a\r", "\nb", "\r"},
+			expected: "|a\n|b\r",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var w bytes.Buffer
+
+			lw := LineWriter(&w, tt.format)
+			for _, s := range tt.writes {
+				n, err := lw.Write([]byte(s))
+				assert.Nil(t, err)
+				assert.Equals(t, n, len(s))
+			}
+			assert.Nil(t, lw.Close())
+			assert.Equals(t, w.String(), tt.expected)
+		})
+	}
+}
+
+func TestWriteData(t *testing.T) {
+	t.Run("KeepsLeadingLineBreak", func(t *testing.T) {
+		var w bytes.Buffer
+
+		lw := LineWriter(&w, FormatOptions{prefix: "> ", lineEnding: "\r\n"})
+		_, err := lw.Write([]byte("a\n"))
+		assert.Nil(t, err)
+		n, err := writeData(lw, []byte("\nb"))
+		assert.Nil(t, err)
+		assert.Equals(t, n, 2)
+		_, err = lw.Write([]byte("\nc"))
+		assert.Nil(t, err)
+
+		assert.Equals(t, w.String(), "> a\r\n> \nb\r\n> c")
+	})
+
+	t.Run("WritesToOtherWriters", func(t *testing.T) {
+		var w bytes.Buffer
+
+		_, err := writeData(&w, []byte("\na"))
+
+		assert.Nil(t, err)
+		assert.Equals(t, w.String(), "\na")
+	})
+}
+
+func TestOutputWriter(t *testing.T) {
+	t.Run("AppliesLinesThenCharset", func(t *testing.T) {
+		var w bytes.Buffer
+
+		options := NewOptions().Use(Mime("text/plain", "iso-8859-1"), Format(Prefix("é "), LineEnding("\r\n")))
+		out, err := OutputWriter(&w, options)
+		assert.Nil(t, err)
+
+		_, err = io.WriteString(out, "à\nç")
+		assert.Nil(t, err)
+		assert.Nil(t, out.Close())
+		assert.Equals(t, w.String(), "\xe9 \xe0\r\n\xe9 \xe7")
+	})
+
+	t.Run("ReturnsCharsetErrors", func(t *testing.T) {
+		_, err := OutputWriter(io.Discard, NewOptions().Use(Mime("text/plain", "koi8-r")))
+
+		assert.ErrorIs(t, err, ErrUnsupportedCharset)
+	})
+}
@@ -0,0 +1,619 @@
+// Copyright 2025 The Nanoninja Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+package render
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"reflect"
+	"strconv"
+)
+
+// Position is a GeoJSON position: longitude, latitude and an optional
+// altitude, in this order.
+type Position []float64
+
+// Geometry is a GeoJSON geometry: Point, MultiPoint, LineString,
+// MultiLineString, Polygon, MultiPolygon or GeometryCollection.
+type Geometry interface {
+	// Type returns the GeoJSON type of the geometry, such as "Point".
+	Type() string
+
+	// encode writes the members of the geometry following its type.
+	encode(e *geoEncoder, m *geoMembers) error
+}
+
+// Point is a single position.
+type Point struct {
+	Coordinates Position
+}
+
+// MultiPoint is a set of positions.
+type MultiPoint struct {
+	Coordinates []Position
+}
+
+// LineString is a line through two or more positions.
+type LineString struct {
+	Coordinates []Position
+}
+
+// MultiLineString is a set of lines.
+type MultiLineString struct {
+	Coordinates [][]Position
+}
+
+// Polygon is an area delimited by linear rings: an exterior ring, followed
+// by the rings of its holes. Rings are closed, ending with their first
+// position, and hold at least four positions.
+type Polygon struct {
+	Coordinates [][]Position
+}
+
+// MultiPolygon is a set of polygons.
+type MultiPolygon struct {
+	Coordinates [][][]Position
+}
+
+// GeometryCollection is a set of geometries of any type.
+type GeometryCollection struct {
+	Geometries []Geometry
+}
+
+// Type returns "Point".
+func (Point) Type() string { return "Point" }
+
+// Type returns "MultiPoint".
+func (MultiPoint) Type() string { return "MultiPoint" }
+
+// Type returns "LineString".
+func (LineString) Type() string { return "LineString" }
+
+// Type returns "MultiLineString".
+func (MultiLineString) Type() string { return "MultiLineString" }
+
+// Type returns "Polygon".
+func (Polygon) Type() string { return "Polygon" }
+
+// Type returns "MultiPolygon".
+func (MultiPolygon) Type() string { return "MultiPolygon" }
+
+// Type returns "GeometryCollection".
+func (GeometryCollection) Type() string { return "GeometryCollection" }
+
+// Feature is a geometry with properties.
+//
+// Example:
+//
+//	render.GeoJSON().Render(w, render.Feature{
+//	    ID:         "eiffel",
+//	    Geometry:   render.Point{Coordinates: render.Position{2.2945, 48.8584}},
+//	    Properties: map[string]any{"name": "Eiffel Tower"},
+//	})
+type Feature struct {
+	// ID identifies the feature. It is a string or a number, and is
+	// omitted when nil.
+	ID any
+
+	// Geometry is the geometry of the feature, written as null when nil.
+	Geometry Geometry
+
+	// Properties are encoded as a JSON object, written as null when nil.
+	Properties any
+
+	// Foreign holds additional members of the feature, written after the
+	// standard ones and sorted by name.
+	Foreign map[string]any
+}
+
+// FeatureCollection is a set of features.
+type FeatureCollection struct {
+	Features []Feature
+
+	// Foreign holds additional members of the collection, written after
+	// the standard ones and sorted by name.
+	Foreign map[string]any
+}
+
+// GeoJSONConfig defines configuration options for GeoJSON rendering.
+type GeoJSONConfig struct {
+	// Precision is the maximum number of decimal places of coordinates,
+	// such as 6 for about 10 cm. Coordinates are rounded to it. Values
+	// lower than one keep full precision.
+	Precision int
+
+	// BBox writes the bounding box of every feature and feature collection,
+	// and of geometries rendered on their own.
+	BBox bool
+}
+
+// geojsonRenderer implements the rendering of GeoJSON objects on top of
+// the JSON renderer.
+type geojsonRenderer struct {
+	config GeoJSONConfig
+	json   Renderer
+}
+
+// GeoJSON creates a new GeoJSON renderer keeping full precision and
+// without bounding boxes. This is the recommended constructor for most
+// use cases.
+func GeoJSON() Renderer {
+	return NewGeoJSON(GeoJSONConfig{})
+}
+
+// NewGeoJSON creates a GeoJSON renderer with custom configuration.
+//
+// Example:
+//
+//	renderer := render.NewGeoJSON(render.GeoJSONConfig{Precision: 6, BBox: true})
+func NewGeoJSON(c GeoJSONConfig) Renderer {
+	return &geojsonRenderer{config: c, json: Bind(JSON(), MimeGeoJSON())}
+}
+
+// Render writes data as GeoJSON using a background context.
+// Data must be a Geometry, a Feature, a FeatureCollection, or a []Feature
+// written as a feature collection.
+func (r *geojsonRenderer) Render(w io.Writer, data any, opts ...func(*Options)) error {
+	return r.RenderContext(context.Background(), w, data, opts...)
+}
+
+// RenderContext writes data as GeoJSON (RFC 7946) with context support.
+// The object is validated before anything is written: positions hold two
+// or three coordinates, the same number across the object, line strings
+// hold at least two positions, and polygon rings are closed with at least
+// four positions. Invalid objects return an error wrapping ErrInvalidData,
+// and foreign members named as standard members an error wrapping
+// ErrInvalidKey.
+// The object is written by the JSON renderer, honoring its format options.
+// The content type is application/geo+json.
+func (r *geojsonRenderer) RenderContext(ctx context.Context, w io.Writer, data any, opts ...func(*Options)) error {
+	if err := CheckContext(ctx); err != nil {
+		return err
+	}
+	e := &geoEncoder{config: r.config}
+	raw, err := e.encode(data)
+	if err != nil {
+		return err
+	}
+	return r.json.RenderContext(ctx, w, raw, opts...)
+}
+
+// geoEncoder writes GeoJSON objects as JSON text.
+type geoEncoder struct {
+	config GeoJSONConfig
+	dims   int // Number of coordinates of the positions, once known
+}
+
+// encode validates and encodes a GeoJSON object.
+func (e *geoEncoder) encode(data any) (json.RawMessage, error) {
+	var m geoMembers
+	var err error
+	switch v := data.(type) {
+	case Feature:
+		err = e.feature(&m, &v, e.config.BBox)
+	case *Feature:
+		if v == nil {
+			return nil, ErrInvalidData
+		}
+		err = e.feature(&m, v, e.config.BBox)
+	case FeatureCollection:
+		err = e.collection(&m, &v)
+	case *FeatureCollection:
+		if v == nil {
+			return nil, ErrInvalidData
+		}
+		err = e.collection(&m, v)
+	case []Feature:
+		err = e.collection(&m, &FeatureCollection{Features: v})
+	case Geometry:
+		if isNilGeometry(v) {
+			return nil, ErrInvalidData
+		}
+		err = e.geometry(&m, v, e.config.BBox)
+	default:
+		return nil, ErrInvalidData
+	}
+	if err != nil {
+		return nil, err
+	}
+	return m.bytes()
+}
+
+// collection writes the members of a feature collection.
+func (e *geoEncoder) collection(m *geoMembers, c *FeatureCollection) error {
+	features := make([]json.RawMessage, len(c.Features))
+	box := newGeoBox()
+	for i := range c.Features {
+		var fm geoMembers
+		err := e.feature(&fm, &c.Features[i], e.config.BBox)
+		if err == nil {
+			features[i], err = fm.bytes()
+		}
+		if err != nil {
+			return fmt.Errorf("feature %d: %w", i, err)
+		}
+		if g := c.Features[i].Geometry; e.config.BBox && !isNilGeometry(g) {
+			box.addGeometry(g)
+		}
+	}
+	m.add("type", "FeatureCollection")
+	if e.config.BBox {
+		e.addBox(m, box)
+	}
+	m.add("features", features)
+	return e.foreign(m, c.Foreign, "features")
+}
+
+// feature writes the members of a feature, with its bounding box when
+// bbox is set.
+func (e *geoEncoder) feature(m *geoMembers, f *Feature, bbox bool) error {
+	m.add("type", "Feature")
+	switch f.ID.(type) {
+	case nil:
+	case string, int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64, float32, float64, json.Number:
+		m.add("id", f.ID)
+	default:
+		return fmt.Errorf("%w: feature id of type %T", ErrInvalidData, f.ID)
+	}
+	if isNilGeometry(f.Geometry) {
+		m.add("geometry", nil)
+	} else {
+		if bbox {
+			box := newGeoBox()
+			box.addGeometry(f.Geometry)
+			e.addBox(m, box)
+		}
+		var gm geoMembers
+		if err := e.geometry(&gm, f.Geometry, false); err != nil {
+			return err
+		}
+		geometry, err := gm.bytes()
+		if err != nil {
+			return err
+		}
+		m.add("geometry", geometry)
+	}
+	m.add("properties", f.Properties)
+	return e.foreign(m, f.Foreign, "geometry", "properties")
+}
+
+// geometry writes the members of g, with its bounding box when bbox is set.
+func (e *geoEncoder) geometry(m *geoMembers, g Geometry, bbox bool) error {
+	m.add("type", g.Type())
+	if bbox {
+		box := newGeoBox()
+		box.addGeometry(g)
+		e.addBox(m, box)
+	}
+	return g.encode(e, m)
+}
+
+// foreign adds the foreign members of an object, sorted by name. Names of
+// standard members are rejected.
+func (e *geoEncoder) foreign(m *geoMembers, members map[string]any, reserved ...string) error {
+	for _, name := range sortedKeys(members) {
+		switch name {
+		case "type", "id", "bbox", "coordinates", "geometries":
+			return fmt.Errorf("%w: foreign member %q", ErrInvalidKey, name)
+		}
+		for _, r := range reserved {
+			if name == r {
+				return fmt.Errorf("%w: foreign member %q", ErrInvalidKey, name)
+			}
+		}
+		m.add(name, members[name])
+	}
+	return nil
+}
+
+// addBox adds the bbox member of a non-empty box.
+func (e *geoEncoder) addBox(m *geoMembers, box *geoBox) {
+	if box.empty() {
+		return
+	}
+	var b bytes.Buffer
+	b.WriteByte('[')
+	for i, v := range append(box.min[:box.dims:box.dims], box.max[:box.dims]...) {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+		b.WriteString(e.coordinate(v))
+	}
+	b.WriteByte(']')
+	m.add("bbox", json.RawMessage(b.Bytes()))
+}
+
+// position writes a position, checking its number of coordinates.
+func (e *geoEncoder) position(b *bytes.Buffer, p Position) error {
+	if len(p) < 2 || len(p) > 3 {
+		return fmt.Errorf("%w: position with %d coordinates", ErrInvalidData, len(p))
+	}
+	if e.dims == 0 {
+		e.dims = len(p)
+	} else if len(p) != e.dims {
+		return fmt.Errorf("%w: position with %d coordinates, want %d", ErrInvalidData, len(p), e.dims)
+	}
+	b.WriteByte('[')
+	for i, v := range p {
+		if math.IsNaN(v) || math.IsInf(v, 0) {
+			return fmt.Errorf("%w: coordinate %v", ErrInvalidData, v)
+		}
+		if i > 0 {
+			b.WriteByte(',')
+		}
+		b.WriteString(e.coordinate(v))
+	}
+	b.WriteByte(']')
+	return nil
+}
+
+// positions writes a list of positions, holding at least min positions.
+// Closed lists end with their first position.
+func (e *geoEncoder) positions(b *bytes.Buffer, ps []Position, min int, closed bool) error {
+	if len(ps) < min {
+		return fmt.Errorf("%w: %d positions, want at least %d", ErrInvalidData, len(ps), min)
+	}
+	if closed && !equalPositions(ps[0], ps[len(ps)-1]) {
+		return fmt.Errorf("%w: linear ring is not closed", ErrInvalidData)
+	}
+	b.WriteByte('[')
+	for i, p := range ps {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+		if err := e.position(b, p); err != nil {
+			return err
+		}
+	}
+	b.WriteByte(']')
+	return nil
+}
+
+// rings writes the linear rings of a polygon.
+func (e *geoEncoder) rings(b *bytes.Buffer, rings [][]Position) error {
+	b.WriteByte('[')
+	for i, ring := range rings {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+		if err := e.positions(b, ring, 4, true); err != nil {
+			return err
+		}
+	}
+	b.WriteByte(']')
+	return nil
+}
+
+// coordinate formats a coordinate, rounded to the configured precision.
+func (e *geoEncoder) coordinate(v float64) string {
+	if p := e.config.Precision; p > 0 {
+		scale := math.Pow(10, float64(p))
+		v = math.Round(v*scale) / scale
+	}
+	return strconv.FormatFloat(v, 'f', -1, 64)
+}
+
+func (g Point) encode(e *geoEncoder, m *geoMembers) error {
+	var b bytes.Buffer
+	if err := e.position(&b, g.Coordinates); err != nil {
+		return err
+	}
+	m.add("coordinates", json.RawMessage(b.Bytes()))
+	return nil
+}
+
+func (g MultiPoint) encode(e *geoEncoder, m *geoMembers) error {
+	var b bytes.Buffer
+	if err := e.positions(&b, g.Coordinates, 0, false); err != nil {
+		return err
+	}
+	m.add("coordinates", json.RawMessage(b.Bytes()))
+	return nil
+}
+
+func (g LineString) encode(e *geoEncoder, m *geoMembers) error {
+	var b bytes.Buffer
+	if err := e.positions(&b, g.Coordinates, 2, false); err != nil {
+		return err
+	}
+	m.add("coordinates", json.RawMessage(b.Bytes()))
+	return nil
+}
+
+func (g MultiLineString) encode(e *geoEncoder, m *geoMembers) error {
+	var b bytes.Buffer
+	b.WriteByte('[')
+	for i, line := range g.Coordinates {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+		if err := e.positions(&b, line, 2, false); err != nil {
+			return err
+		}
+	}
+	b.WriteByte(']')
+	m.add("coordinates", json.RawMessage(b.Bytes()))
+	return nil
+}
+
+func (g Polygon) encode(e *geoEncoder, m *geoMembers) error {
+	var b bytes.Buffer
+	if err := e.rings(&b, g.Coordinates); err != nil {
+		return err
+	}
+	m.add("coordinates", json.RawMessage(b.Bytes()))
+	return nil
+}
+
+func (g MultiPolygon) encode(e *geoEncoder, m *geoMembers) error {
+	var b bytes.Buffer
+	b.WriteByte('[')
+	for i, polygon := range g.Coordinates {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+		if err := e.rings(&b, polygon); err != nil {
+			return err
+		}
+	}
+	b.WriteByte(']')
+	m.add("coordinates", json.RawMessage(b.Bytes()))
+	return nil
+}
+
+func (g GeometryCollection) encode(e *geoEncoder, m *geoMembers) error {
+	geometries := make([]json.RawMessage, len(g.Geometries))
+	for i, child := range g.Geometries {
+		if isNilGeometry(child) {
+			return fmt.Errorf("%w: nil geometry in collection", ErrInvalidData)
+		}
+		var gm geoMembers
+		err := e.geometry(&gm, child, false)
+		if err == nil {
+			geometries[i], err = gm.bytes()
+		}
+		if err != nil {
+			return err
+		}
+	}
+	m.add("geometries", geometries)
+	return nil
+}
+
+// geoMembers builds a JSON object keeping the order of its members.
+type geoMembers struct {
+	b   bytes.Buffer
+	err error // First error encoding a member
+}
+
+// add adds a member, encoding its value with encoding/json.
+func (m *geoMembers) add(name string, value any) {
+	raw, err := json.Marshal(value)
+	if err != nil {
+		if m.err == nil {
+			m.err = fmt.Errorf("%w: member %q: %v", ErrInvalidData, name, err)
+		}
+		return
+	}
+	if m.b.Len() == 0 {
+		m.b.WriteByte('{')
+	} else {
+		m.b.WriteByte(',')
+	}
+	key, _ := json.Marshal(name)
+	m.b.Write(key)
+	m.b.WriteByte(':')
+	m.b.Write(raw)
+}
+
+// bytes returns the JSON text of the object, or the first error encoding
+// its members.
+func (m *geoMembers) bytes() (json.RawMessage, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	if m.b.Len() == 0 {
+		return json.RawMessage("{}"), nil
+	}
+	return json.RawMessage(append(m.b.Bytes(), '}')), nil
+}
+
+// geoBox is the bounding box of a set of positions.
+type geoBox struct {
+	min, max [3]float64
+	dims     int // Largest number of coordinates of the positions
+}
+
+func newGeoBox() *geoBox {
+	inf := math.Inf(1)
+	return &geoBox{min: [3]float64{inf, inf, inf}, max: [3]float64{-inf, -inf, -inf}}
+}
+
+// empty reports whether no position was added to the box.
+func (b *geoBox) empty() bool {
+	return b.min[0] > b.max[0]
+}
+
+// add extends the box to the positions.
+func (b *geoBox) add(ps ...Position) {
+	for _, p := range ps {
+		if len(p) > b.dims && len(p) <= 3 {
+			b.dims = len(p)
+		}
+		for i := 0; i < len(p) && i < 3; i++ {
+			b.min[i] = math.Min(b.min[i], p[i])
+			b.max[i] = math.Max(b.max[i], p[i])
+		}
+	}
+}
+
+// addGeometry extends the box to the positions of g.
+func (b *geoBox) addGeometry(g Geometry) {
+	switch g := g.(type) {
+	case Point:
+		b.add(g.Coordinates)
+	case *Point:
+		b.addGeometry(*g)
+	case MultiPoint:
+		b.add(g.Coordinates...)
+	case *MultiPoint:
+		b.addGeometry(*g)
+	case LineString:
+		b.add(g.Coordinates...)
+	case *LineString:
+		b.addGeometry(*g)
+	case MultiLineString:
+		for _, line := range g.Coordinates {
+			b.add(line...)
+		}
+	case *MultiLineString:
+		b.addGeometry(*g)
+	case Polygon:
+		for _, ring := range g.Coordinates {
+			b.add(ring...)
+		}
+	case *Polygon:
+		b.addGeometry(*g)
+	case MultiPolygon:
+		for _, polygon := range g.Coordinates {
+			b.addGeometry(Polygon{Coordinates: polygon})
+		}
+	case *MultiPolygon:
+		b.addGeometry(*g)
+	case GeometryCollection:
+		for _, child := range g.Geometries {
+			if !isNilGeometry(child) {
+				b.addGeometry(child)
+			}
+		}
+	case *GeometryCollection:
+		b.addGeometry(*g)
+	}
+}
+
+// equalPositions reports whether a and b hold the same coordinates.
+func equalPositions(a, b Position) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// isNilGeometry reports whether g is nil or a nil pointer.
+func isNilGeometry(g Geometry) bool {
+	if g == nil {
+		return true
+	}
+	v := reflect.ValueOf(g)
+	return v.Kind() == reflect.Pointer && v.IsNil()
+}
@@ -0,0 +1,206 @@
+// Copyright 2025 The Nanoninja Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+package render
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"math"
+	"testing"
+
+	"github.com/nanoninja/assert"
+)
+
+var (
+	_ Renderer = (*geojsonRenderer)(nil)
+	_ Renderer = GeoJSON()
+
+	_ Geometry = Point{}
+	_ Geometry = MultiPoint{}
+	_ Geometry = LineString{}
+	_ Geometry = MultiLineString{}
+	_ Geometry = Polygon{}
+	_ Geometry = MultiPolygon{}
+	_ Geometry = GeometryCollection{}
+)
+
+// geoSquare is a closed ring around the origin.
+var geoSquare = []Position{{0, 0}, {1, 0}, {1, 1}, {0, 1}, {0, 0}}
+
+// renderGeoJSON renders data compactly, returning the output.
+func renderGeoJSON(t *testing.T, r Renderer, data any) string {
+	t.Helper()
+	var w bytes.Buffer
+	err := r.Render(&w, data)
+	assert.Nil(t, err)
+	return w.String()
+}
+
+func TestGeoJSONRenderer(t *testing.T) {
+	t.Run("RendersGeometries", func(t *testing.T) {
+		tests := []struct {
+			name     string
+			geometry Geometry
+			expected string
+		}{
+			{"Point", Point{Coordinates: Position{2.2945, 48.8584}}, `{"type":"Point","coordinates":[2.2945,48.8584]}`},
+			{"PointPointer", &Point{Coordinates: Position{1, 2, 3}}, `{"type":"Point","coordinates":[1,2,3]}`},
+			{"MultiPoint", MultiPoint{Coordinates: []Position{{1, 2}, {3, 4}}}, `{"type":"MultiPoint","coordinates":[[1,2],[3,4]]}`},
+			{"LineString", LineString{Coordinates: []Position{{1, 2}, {3, 4}}}, `{"type":"LineString","coordinates":[[1,2],[3,4]]}`},
+			{"MultiLineString", MultiLineString{Coordinates: [][]Position{{{1, 2}, {3, 4}}}}, `{"type":"MultiLineString","coordinates":[[[1,2],[3,4]]]}`},
+			{"Polygon", Polygon{Coordinates: [][]Position{geoSquare}}, `{"type":"Polygon","coordinates":[[[0,0],[1,0],[1,1],[0,1],[0,0]]]}`},
+			{"MultiPolygon", MultiPolygon{Coordinates: [][][]Position{{geoSquare}}}, `{"type":"MultiPolygon","coordinates":[[[[0,0],[1,0],[1,1],[0,1],[0,0]]]]}`},
+			{
+				"GeometryCollection",
+				GeometryCollection{Geometries: []Geometry{Point{Coordinates: Position{1, 2}}, LineString{Coordinates: []Position{{1, 2}, {3, 4}}}}},
+				`{"type":"GeometryCollection","geometries":[{"type":"Point","coordinates":[1,2]},{"type":"LineString","coordinates":[[1,2],[3,4]]}]}`,
+			},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				assert.Equals(t, renderGeoJSON(t, GeoJSON(), tt.geometry), tt.expected+"\n")
+			})
+		}
+	})
+
+	t.Run("RendersFeatures", func(t *testing.T) {
+		feature := Feature{
+			ID:         42,
+			Geometry:   Point{Coordinates: Position{1, 2}},
+			Properties: map[string]any{"name": "a <b>"},
+			Foreign:    map[string]any{"title": "Example"},
+		}
+
+		got := renderGeoJSON(t, GeoJSON(), &feature)
+
+		assert.Equals(t, got, `{"type":"Feature","id":42,"geometry":{"type":"Point","coordinates":[1,2]},`+
+			`"properties":{"name":"a \u003cb\u003e"},"title":"Example"}`+"\n")
+	})
+
+	t.Run("RendersFeatureCollections", func(t *testing.T) {
+		got := renderGeoJSON(t, GeoJSON(), []Feature{{Geometry: nil}, {ID: "b", Geometry: Point{Coordinates: Position{1, 2}}}})
+
+		assert.Equals(t, got, `{"type":"FeatureCollection","features":[`+
+			`{"type":"Feature","geometry":null,"properties":null},`+
+			`{"type":"Feature","id":"b","geometry":{"type":"Point","coordinates":[1,2]},"properties":null}]}`+"\n")
+	})
+
+	t.Run("RendersEmptyCollections", func(t *testing.T) {
+		got := renderGeoJSON(t, NewGeoJSON(GeoJSONConfig{BBox: true}), FeatureCollection{})
+
+		assert.Equals(t, got, `{"type":"FeatureCollection","features":[]}`+"\n")
+	})
+
+	t.Run("ComputesBoundingBoxes", func(t *testing.T) {
+		r := NewGeoJSON(GeoJSONConfig{BBox: true})
+		collection := FeatureCollection{Features: []Feature{
+			{Geometry: Point{Coordinates: Position{-1, 5}}},
+			{Geometry: Polygon{Coordinates: [][]Position{geoSquare}}},
+		}}
+
+		got := renderGeoJSON(t, r, collection)
+
+		assert.Equals(t, got, `{"type":"FeatureCollection","bbox":[-1,0,1,5],"features":[`+
+			`{"type":"Feature","bbox":[-1,5,-1,5],"geometry":{"type":"Point","coordinates":[-1,5]},"properties":null},`+
+			`{"type":"Feature","bbox":[0,0,1,1],"geometry":{"type":"Polygon","coordinates":[[[0,0],[1,0],[1,1],[0,1],[0,0]]]},"properties":null}]}`+"\n")
+	})
+
+	t.Run("ComputesThreeDimensionalBoundingBoxes", func(t *testing.T) {
+		r := NewGeoJSON(GeoJSONConfig{BBox: true})
+
+		got := renderGeoJSON(t, r, LineString{Coordinates: []Position{{1, 2, 30}, {-1, 4, 10}}})
+
+		assert.Equals(t, got, `{"type":"LineString","bbox":[-1,2,10,1,4,30],"coordinates":[[1,2,30],[-1,4,10]]}`+"\n")
+	})
+
+	t.Run("LimitsPrecision", func(t *testing.T) {
+		r := NewGeoJSON(GeoJSONConfig{Precision: 3, BBox: true})
+
+		got := renderGeoJSON(t, r, Point{Coordinates: Position{2.29451234, -48.85849}})
+
+		assert.Equals(t, got, `{"type":"Point","bbox":[2.295,-48.858,2.295,-48.858],"coordinates":[2.295,-48.858]}`+"\n")
+	})
+
+	t.Run("HonorsFormatOptions", func(t *testing.T) {
+		var (
+			w       bytes.Buffer
+			options *Options
+		)
+
+		err := GeoJSON().Render(&w, Point{Coordinates: Position{1, 2}}, Format(Pretty()), CaptureOptions(&options))
+
+		assert.Nil(t, err)
+		assert.Equals(t, options.ContentType(), "application/geo+json; charset=utf-8")
+		var decoded map[string]any
+		assert.Nil(t, json.Unmarshal(w.Bytes(), &decoded))
+		assert.StringContains(t, w.String(), "\n  \"coordinates\": [\n")
+	})
+
+	t.Run("AllowsContentTypeOverride", func(t *testing.T) {
+		var (
+			w       bytes.Buffer
+			options *Options
+		)
+
+		err := GeoJSON().Render(&w, Point{Coordinates: Position{1, 2}}, MimeJSON(), CaptureOptions(&options))
+
+		assert.Nil(t, err)
+		assert.Equals(t, options.ContentType(), "application/json; charset=utf-8")
+	})
+
+	t.Run("RejectsInvalidGeometries", func(t *testing.T) {
+		tests := map[string]any{
+			"EmptyPosition":     Point{},
+			"OneCoordinate":     Point{Coordinates: Position{1}},
+			"FourCoordinates":   Point{Coordinates: Position{1, 2, 3, 4}},
+			"MixedDimensions":   LineString{Coordinates: []Position{{1, 2}, {3, 4, 5}}},
+			"ShortLineString":   LineString{Coordinates: []Position{{1, 2}}},
+			"OpenRing":          Polygon{Coordinates: [][]Position{{{0, 0}, {1, 0}, {1, 1}, {0, 1}}}},
+			"ShortRing":         Polygon{Coordinates: [][]Position{{{0, 0}, {1, 0}, {0, 0}}}},
+			"NaN":               Point{Coordinates: Position{math.NaN(), 0}},
+			"NilPointer":        (*Point)(nil),
+			"NilInCollection":   GeometryCollection{Geometries: []Geometry{nil}},
+			"InvalidFeature":    []Feature{{Geometry: Point{}}},
+			"InvalidID":         Feature{ID: []int{1}},
+			"InvalidProperties": Feature{Properties: map[string]any{"f": func() {}}},
+			"NotGeoJSON":        map[string]any{"type": "Point"},
+		}
+		for name, data := range tests {
+			t.Run(name, func(t *testing.T) {
+				var w bytes.Buffer
+
+				err := GeoJSON().Render(&w, data)
+
+				assert.ErrorIs(t, err, ErrInvalidData)
+				assert.Equals(t, w.Len(), 0)
+			})
+		}
+	})
+
+	t.Run("RejectsReservedForeignMembers", func(t *testing.T) {
+		for _, data := range []any{
+			Feature{Foreign: map[string]any{"geometry": 1}},
+			FeatureCollection{Foreign: map[string]any{"type": "x"}},
+		} {
+			var w bytes.Buffer
+
+			err := GeoJSON().Render(&w, data)
+
+			assert.ErrorIs(t, err, ErrInvalidKey)
+			assert.Equals(t, w.Len(), 0)
+		}
+	})
+
+	t.Run("ReturnsContextError", func(t *testing.T) {
+		var w bytes.Buffer
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		err := GeoJSON().RenderContext(ctx, &w, Point{Coordinates: Position{1, 2}})
+
+		assert.ErrorIs(t, err, context.Canceled)
+		assert.Equals(t, w.Len(), 0)
+	})
+}
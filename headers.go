@@ -0,0 +1,426 @@
+// Copyright 2025 The Nanoninja Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+package render
+
+import (
+	"fmt"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	// languageTagPattern matches the syntax of a BCP 47 language tag.
+	languageTagPattern = regexp.MustCompile(`^[A-Za-z]{1,8}(-[A-Za-z0-9]{1,8})*$`)
+
+	// directiveNamePattern matches a Content-Security-Policy directive name.
+	directiveNamePattern = regexp.MustCompile(`^[a-z0-9-]+$`)
+)
+
+// CacheDirective is a single Cache-Control directive created by functions
+// such as MaxAge, Private or NoCache.
+type CacheDirective struct {
+	name  string // Directive name, such as max-age
+	value string // Optional directive value
+	err   error  // Validation error, if any
+}
+
+// MaxAge returns the max-age directive, the duration during which
+// a response is considered fresh. The duration is rounded down to seconds.
+func MaxAge(d time.Duration) CacheDirective {
+	return secondsDirective("max-age", d)
+}
+
+// SMaxAge returns the s-maxage directive, which overrides max-age
+// for shared caches such as proxies and CDNs.
+func SMaxAge(d time.Duration) CacheDirective {
+	return secondsDirective("s-maxage", d)
+}
+
+// StaleWhileRevalidate returns the stale-while-revalidate directive, which
+// allows caches to serve a stale response while revalidating it.
+func StaleWhileRevalidate(d time.Duration) CacheDirective {
+	return secondsDirective("stale-while-revalidate", d)
+}
+
+// Public returns the public directive. It replaces a private directive.
+func Public() CacheDirective { return CacheDirective{name: "public"} }
+
+// Private returns the private directive. It replaces a public directive.
+func Private() CacheDirective { return CacheDirective{name: "private"} }
+
+// NoCache returns the no-cache directive, which requires caches to
+// revalidate the response before each use.
+func NoCache() CacheDirective { return CacheDirective{name: "no-cache"} }
+
+// MustRevalidate returns the must-revalidate directive, which forbids
+// serving a stale response without revalidating it.
+func MustRevalidate() CacheDirective { return CacheDirective{name: "must-revalidate"} }
+
+// Immutable returns the immutable directive, which tells clients that
+// the response will not change while it is fresh.
+func Immutable() CacheDirective { return CacheDirective{name: "immutable"} }
+
+// NoTransform returns the no-transform directive, which forbids
+// intermediaries from modifying the response.
+func NoTransform() CacheDirective { return CacheDirective{name: "no-transform"} }
+
+// secondsDirective builds a directive whose value is a number of seconds.
+func secondsDirective(name string, d time.Duration) CacheDirective {
+	if d < 0 {
+		return CacheDirective{name: name, err: fmt.Errorf("cache-control %s %v: %w", name, d, ErrInvalidParam)}
+	}
+	return CacheDirective{name: name, value: strconv.FormatInt(int64(d/time.Second), 10)}
+}
+
+// String returns the directive as it appears in the Cache-Control header.
+func (d CacheDirective) String() string {
+	if d.value == "" {
+		return d.name
+	}
+	return d.name + "=" + d.value
+}
+
+// CacheControl returns an option function that adds directives to the
+// Cache-Control header. Directives are merged with those already set:
+//   - A directive given again replaces its previous value
+//   - public and private replace each other
+//   - A previous no-store set by NoStore is kept, so that responses
+//     forbidden from caching are never made cacheable by a later option
+//
+// Invalid directives, such as a negative max-age, are reported by
+// Options.Err and abort rendering.
+//
+// Example:
+//
+//	// Cache-Control: private, max-age=60, must-revalidate
+//	renderer.Render(w, data, render.CacheControl(
+//	    render.Private(),
+//	    render.MaxAge(time.Minute),
+//	    render.MustRevalidate(),
+//	))
+func CacheControl(directives ...CacheDirective) func(*Options) {
+	return func(o *Options) {
+		current := parseCacheControl(o.header.Get("Cache-Control"))
+		for _, d := range directives {
+			if d.err != nil {
+				o.fail(d.err)
+				return
+			}
+			switch d.name {
+			case "public":
+				current = removeDirective(current, "private")
+			case "private":
+				current = removeDirective(current, "public")
+			}
+			current = setDirective(current, d)
+		}
+		if len(current) == 0 {
+			return
+		}
+		values := make([]string, len(current))
+		for i, d := range current {
+			values[i] = d.String()
+		}
+		o.header.Set("Cache-Control", strings.Join(values, ", "))
+	}
+}
+
+// NoStore returns an option function that forbids any caching of the
+// response. It replaces all Cache-Control directives set before it.
+func NoStore() func(*Options) {
+	return func(o *Options) {
+		o.header.Set("Cache-Control", "no-store")
+	}
+}
+
+// parseCacheControl splits a Cache-Control header into its directives.
+func parseCacheControl(header string) []CacheDirective {
+	var directives []CacheDirective
+	for _, part := range strings.Split(header, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		name, value, _ := strings.Cut(part, "=")
+		directives = append(directives, CacheDirective{
+			name:  strings.ToLower(strings.TrimSpace(name)),
+			value: strings.TrimSpace(value),
+		})
+	}
+	return directives
+}
+
+// setDirective replaces the directive with the same name or appends it.
+func setDirective(directives []CacheDirective, d CacheDirective) []CacheDirective {
+	for i := range directives {
+		if directives[i].name == d.name {
+			directives[i] = d
+			return directives
+		}
+	}
+	return append(directives, d)
+}
+
+// removeDirective removes the directive with the given name, if present.
+func removeDirective(directives []CacheDirective, name string) []CacheDirective {
+	kept := directives[:0]
+	for _, d := range directives {
+		if d.name != name {
+			kept = append(kept, d)
+		}
+	}
+	return kept
+}
+
+// Expires returns an option function that sets the Expires header.
+// A zero time is reported by Options.Err. When applied twice, the last
+// value wins.
+func Expires(t time.Time) func(*Options) {
+	return httpDate("Expires", t, false)
+}
+
+// LastModified returns an option function that sets the Last-Modified
+// header. When applied twice, the most recent time is kept, so that the
+// option can be used for each resource composing a response.
+//
+// Example:
+//
+//	renderer.Render(w, data,
+//	    render.LastModified(article.UpdatedAt),
+//	    render.LastModified(author.UpdatedAt),
+//	)
+func LastModified(t time.Time) func(*Options) {
+	return httpDate("Last-Modified", t, true)
+}
+
+// httpDate builds an option setting a date header in the HTTP format.
+// When keepLatest is true, an existing later date is preserved.
+func httpDate(key string, t time.Time, keepLatest bool) func(*Options) {
+	return func(o *Options) {
+		if t.IsZero() {
+			o.fail(fmt.Errorf("%s: zero time: %w", strings.ToLower(key), ErrInvalidParam))
+			return
+		}
+		t = t.UTC().Truncate(time.Second)
+		if keepLatest {
+			if current, err := http.ParseTime(o.header.Get(key)); err == nil && current.After(t) {
+				return
+			}
+		}
+		o.header.Set(key, t.Format(http.TimeFormat))
+	}
+}
+
+// ContentLanguage returns an option function that adds language tags to
+// the Content-Language header. Tags already present are not repeated,
+// regardless of case. Malformed tags are reported by Options.Err.
+//
+// Example:
+//
+//	// Content-Language: en-US, fr
+//	renderer.Render(w, data, render.ContentLanguage("en-US", "fr"))
+func ContentLanguage(tags ...string) func(*Options) {
+	return func(o *Options) {
+		current := splitList(o.header.Get("Content-Language"))
+		for _, tag := range tags {
+			if !languageTagPattern.MatchString(tag) {
+				o.fail(fmt.Errorf("content-language %q: %w", tag, ErrInvalidParam))
+				return
+			}
+			if !containsFold(current, tag) {
+				current = append(current, tag)
+			}
+		}
+		if len(current) > 0 {
+			o.header.Set("Content-Language", strings.Join(current, ", "))
+		}
+	}
+}
+
+// NoSniff returns an option function that sets the X-Content-Type-Options
+// header to nosniff, preventing browsers from guessing the content type.
+func NoSniff() func(*Options) {
+	return func(o *Options) {
+		o.header.Set("X-Content-Type-Options", "nosniff")
+	}
+}
+
+// CSPDirective is a single Content-Security-Policy directive with its
+// list of sources or values.
+type CSPDirective struct {
+	name   string
+	values []string
+}
+
+// CSPRule returns a Content-Security-Policy directive with the given name
+// and values. Use it for directives without a dedicated helper.
+//
+// Example:
+//
+//	render.CSPRule("upgrade-insecure-requests")
+//	render.CSPRule("form-action", "'self'")
+func CSPRule(name string, values ...string) CSPDirective {
+	return CSPDirective{name: name, values: values}
+}
+
+// DefaultSrc returns the default-src directive.
+func DefaultSrc(sources ...string) CSPDirective { return CSPRule("default-src", sources...) }
+
+// ScriptSrc returns the script-src directive.
+func ScriptSrc(sources ...string) CSPDirective { return CSPRule("script-src", sources...) }
+
+// StyleSrc returns the style-src directive.
+func StyleSrc(sources ...string) CSPDirective { return CSPRule("style-src", sources...) }
+
+// ImgSrc returns the img-src directive.
+func ImgSrc(sources ...string) CSPDirective { return CSPRule("img-src", sources...) }
+
+// ConnectSrc returns the connect-src directive.
+func ConnectSrc(sources ...string) CSPDirective { return CSPRule("connect-src", sources...) }
+
+// FrameAncestors returns the frame-ancestors directive.
+func FrameAncestors(sources ...string) CSPDirective { return CSPRule("frame-ancestors", sources...) }
+
+// validate reports whether the directive can be written in a header.
+func (d CSPDirective) validate() error {
+	if !directiveNamePattern.MatchString(d.name) {
+		return fmt.Errorf("content-security-policy directive %q: %w", d.name, ErrInvalidParam)
+	}
+	for _, v := range d.values {
+		if v == "" || strings.ContainsAny(v, ";,") || strings.IndexFunc(v, isSpaceOrControl) >= 0 {
+			return fmt.Errorf("content-security-policy %s value %q: %w", d.name, v, ErrInvalidParam)
+		}
+	}
+	return nil
+}
+
+// CSP returns an option function that adds directives to the
+// Content-Security-Policy header. Directive names must be lowercase and
+// values cannot contain spaces, commas or semicolons; invalid directives
+// are reported by Options.Err.
+//
+// Directives are merged with those already set: the values of a directive
+// given again are added to the previous ones, so that policies can be
+// extended. Sources are compared exactly, since paths in source URLs are
+// case-sensitive. As sources are allow-lists, 'none' is dropped once a directive
+// holds any other source.
+//
+// Example:
+//
+//	// Content-Security-Policy: default-src 'self'; img-src 'self' https://cdn.example.com
+//	renderer.Render(w, data, render.CSP(
+//	    render.DefaultSrc("'self'"),
+//	    render.ImgSrc("'self'", "https://cdn.example.com"),
+//	))
+func CSP(directives ...CSPDirective) func(*Options) {
+	return func(o *Options) {
+		current := parseCSP(o.header.Get("Content-Security-Policy"))
+		for _, d := range directives {
+			if err := d.validate(); err != nil {
+				o.fail(err)
+				return
+			}
+			current = mergeCSP(current, d)
+		}
+		if len(current) == 0 {
+			return
+		}
+		parts := make([]string, len(current))
+		for i, d := range current {
+			parts[i] = strings.Join(append([]string{d.name}, d.values...), " ")
+		}
+		o.header.Set("Content-Security-Policy", strings.Join(parts, "; "))
+	}
+}
+
+// parseCSP splits a Content-Security-Policy header into its directives.
+func parseCSP(header string) []CSPDirective {
+	var directives []CSPDirective
+	for _, part := range strings.Split(header, ";") {
+		fields := strings.Fields(part)
+		if len(fields) == 0 {
+			continue
+		}
+		directives = append(directives, CSPDirective{
+			name:   strings.ToLower(fields[0]),
+			values: fields[1:],
+		})
+	}
+	return directives
+}
+
+// mergeCSP adds the values of d to the directive with the same name,
+// or appends d when no such directive exists.
+func mergeCSP(directives []CSPDirective, d CSPDirective) []CSPDirective {
+	for i := range directives {
+		if directives[i].name != d.name {
+			continue
+		}
+		values := directives[i].values
+		for _, v := range d.values {
+			if !contains(values, v) {
+				values = append(values, v)
+			}
+		}
+		directives[i].values = withoutNone(values)
+		return directives
+	}
+	return append(directives, CSPDirective{
+		name:   d.name,
+		values: withoutNone(append([]string(nil), d.values...)),
+	})
+}
+
+// withoutNone removes 'none' from values holding other sources.
+func withoutNone(values []string) []string {
+	if len(values) < 2 {
+		return values
+	}
+	kept := values[:0]
+	for _, v := range values {
+		if !strings.EqualFold(v, "'none'") {
+			kept = append(kept, v)
+		}
+	}
+	return kept
+}
+
+// splitList splits a comma-separated header value.
+func splitList(header string) []string {
+	var values []string
+	for _, v := range strings.Split(header, ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			values = append(values, v)
+		}
+	}
+	return values
+}
+
+// contains reports whether values contains v.
+func contains(values []string, v string) bool {
+	for _, s := range values {
+		if s == v {
+			return true
+		}
+	}
+	return false
+}
+
+// containsFold reports whether values contains v, ignoring case.
+func containsFold(values []string, v string) bool {
+	for _, s := range values {
+		if strings.EqualFold(s, v) {
+			return true
+		}
+	}
+	return false
+}
+
+// isSpaceOrControl reports whether r is whitespace or a control character.
+func isSpaceOrControl(r rune) bool {
+	return r <= ' ' || r == 0x7F
+}
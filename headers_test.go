@@ -0,0 +1,229 @@
+// Copyright 2025 The Nanoninja Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+package render
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/nanoninja/assert"
+)
+
+func TestCacheControl(t *testing.T) {
+	tests := []struct {
+		name     string
+		opts     []func(*Options)
+		expected string
+	}{
+		{
+			name:     "JoinsDirectives",
+			opts:     []func(*Options){CacheControl(Private(), MaxAge(90*time.Second), MustRevalidate())},
+			expected: "private, max-age=90, must-revalidate",
+		},
+		{
+			name: "ReplacesRepeatedDirective",
+			opts: []func(*Options){
+				CacheControl(Public(), MaxAge(time.Hour)),
+				CacheControl(MaxAge(time.Minute)),
+			},
+			expected: "public, max-age=60",
+		},
+		{
+			name: "PrivateReplacesPublic",
+			opts: []func(*Options){
+				CacheControl(Public(), SMaxAge(time.Hour)),
+				CacheControl(Private()),
+			},
+			expected: "s-maxage=3600, private",
+		},
+		{
+			name: "MergesWithRawHeader",
+			opts: []func(*Options){
+				Header(func(h HeaderOptions) { h.Set("Cache-Control", "Max-Age=10, no-transform") }),
+				CacheControl(MaxAge(20*time.Second), Immutable()),
+			},
+			expected: "max-age=20, no-transform, immutable",
+		},
+		{
+			name: "NoStoreReplacesDirectives",
+			opts: []func(*Options){
+				CacheControl(Public(), MaxAge(time.Hour)),
+				NoStore(),
+			},
+			expected: "no-store",
+		},
+		{
+			name: "DirectivesKeepNoStore",
+			opts: []func(*Options){
+				NoStore(),
+				CacheControl(NoCache(), StaleWhileRevalidate(30*time.Second), NoTransform()),
+			},
+			expected: "no-store, no-cache, stale-while-revalidate=30, no-transform",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := NewOptions().Use(tt.opts...)
+
+			assert.Nil(t, opts.Err())
+			assert.Equals(t, opts.Header().Get("Cache-Control"), tt.expected)
+		})
+	}
+
+	t.Run("RejectsNegativeDuration", func(t *testing.T) {
+		opts := NewOptions().Use(CacheControl(Public(), MaxAge(-time.Second)))
+
+		assert.ErrorIs(t, opts.Err(), ErrInvalidParam)
+		assert.Equals(t, opts.Header().Get("Cache-Control"), "")
+	})
+}
+
+func TestDateHeaders(t *testing.T) {
+	older := time.Date(2025, 3, 1, 10, 0, 0, 0, time.UTC)
+	newer := time.Date(2025, 3, 2, 12, 30, 0, 0, time.FixedZone("CET", 3600))
+
+	t.Run("ExpiresUsesHTTPFormat", func(t *testing.T) {
+		opts := NewOptions().Use(Expires(newer))
+
+		assert.Equals(t, opts.Header().Get("Expires"), "Sun, 02 Mar 2025 11:30:00 GMT")
+	})
+
+	t.Run("ExpiresKeepsLastValue", func(t *testing.T) {
+		opts := NewOptions().Use(Expires(newer), Expires(older))
+
+		assert.Equals(t, opts.Header().Get("Expires"), "Sat, 01 Mar 2025 10:00:00 GMT")
+	})
+
+	t.Run("LastModifiedKeepsMostRecent", func(t *testing.T) {
+		opts := NewOptions().Use(LastModified(newer), LastModified(older))
+
+		assert.Equals(t, opts.Header().Get("Last-Modified"), "Sun, 02 Mar 2025 11:30:00 GMT")
+	})
+
+	t.Run("RejectsZeroTime", func(t *testing.T) {
+		opts := NewOptions().Use(LastModified(time.Time{}))
+
+		assert.ErrorIs(t, opts.Err(), ErrInvalidParam)
+		assert.Equals(t, opts.Header().Get("Last-Modified"), "")
+	})
+}
+
+func TestContentLanguage(t *testing.T) {
+	t.Run("MergesTags", func(t *testing.T) {
+		opts := NewOptions().Use(
+			ContentLanguage("en-US", "fr"),
+			ContentLanguage("FR", "de-CH"),
+		)
+
+		assert.Nil(t, opts.Err())
+		assert.Equals(t, opts.Header().Get("Content-Language"), "en-US, fr, de-CH")
+	})
+
+	t.Run("RejectsMalformedTag", func(t *testing.T) {
+		opts := NewOptions().Use(ContentLanguage("en US"))
+
+		assert.ErrorIs(t, opts.Err(), ErrInvalidParam)
+	})
+}
+
+func TestNoSniff(t *testing.T) {
+	opts := NewOptions().Use(NoSniff())
+
+	assert.Equals(t, opts.Header().Get("X-Content-Type-Options"), "nosniff")
+}
+
+func TestCSP(t *testing.T) {
+	t.Run("JoinsDirectives", func(t *testing.T) {
+		opts := NewOptions().Use(CSP(
+			DefaultSrc("'self'"),
+			ImgSrc("'self'", "https://cdn.example.com"),
+			CSPRule("upgrade-insecure-requests"),
+		))
+
+		assert.Nil(t, opts.Err())
+		assert.Equals(t, opts.Header().Get("Content-Security-Policy"),
+			"default-src 'self'; img-src 'self' https://cdn.example.com; upgrade-insecure-requests")
+	})
+
+	t.Run("MergesSources", func(t *testing.T) {
+		opts := NewOptions().Use(
+			CSP(ScriptSrc("'self'"), FrameAncestors("'none'")),
+			CSP(ScriptSrc("'self'", "https://js.example.com"), StyleSrc("'self'")),
+		)
+
+		assert.Equals(t, opts.Header().Get("Content-Security-Policy"),
+			"script-src 'self' https://js.example.com; frame-ancestors 'none'; style-src 'self'")
+	})
+
+	t.Run("ComparesSourcesExactly", func(t *testing.T) {
+		opts := NewOptions().Use(
+			CSP(ScriptSrc("https://example.com/Lib/")),
+			CSP(ScriptSrc("https://example.com/lib/", "https://example.com/Lib/")),
+		)
+
+		assert.Equals(t, opts.Header().Get("Content-Security-Policy"),
+			"script-src https://example.com/Lib/ https://example.com/lib/")
+	})
+
+	t.Run("DropsNoneWhenSourcesAreAdded", func(t *testing.T) {
+		opts := NewOptions().Use(
+			CSP(ConnectSrc("'none'")),
+			CSP(ConnectSrc("https://api.example.com")),
+		)
+
+		assert.Equals(t, opts.Header().Get("Content-Security-Policy"), "connect-src https://api.example.com")
+	})
+
+	t.Run("RejectsInvalidDirectives", func(t *testing.T) {
+		for _, d := range []CSPDirective{
+			CSPRule("Script-Src", "'self'"),
+			CSPRule("", "'self'"),
+			ScriptSrc("'self'; img-src *"),
+			ScriptSrc("a b"),
+			ScriptSrc(""),
+		} {
+			opts := NewOptions().Use(CSP(d))
+
+			assert.ErrorIs(t, opts.Err(), ErrInvalidParam)
+			assert.Equals(t, opts.Header().Get("Content-Security-Policy"), "")
+		}
+	})
+}
+
+func TestHeaderBuilders_Render(t *testing.T) {
+	t.Run("InvalidOptionAbortsRendering", func(t *testing.T) {
+		var w bytes.Buffer
+
+		err := JSON().Render(&w, map[string]int{"a": 1}, CacheControl(MaxAge(-time.Minute)))
+
+		assert.ErrorIs(t, err, ErrInvalidParam)
+		assert.Equals(t, w.Len(), 0)
+	})
+
+	t.Run("DumpListsHeadersAndError", func(t *testing.T) {
+		var dump bytes.Buffer
+
+		err := Text().Render(&bytes.Buffer{}, "hello",
+			NoSniff(),
+			CacheControl(NoCache()),
+			ContentLanguage("en"),
+			Dump(&dump),
+		)
+
+		assert.Nil(t, err)
+		assert.True(t, strings.Contains(dump.String(), `Headers:
+  Cache-Control: no-cache
+  Content-Language: en
+  Content-Type: text/plain; charset=utf-8
+  X-Content-Type-Options: nosniff
+Parameters:
+`))
+
+		dump.Reset()
+		NewOptions().Use(ContentLanguage("en_US"), Dump(&dump))
+		assert.True(t, strings.Contains(dump.String(), `Error: content-language "en_US": invalid parameter value`))
+	})
+}
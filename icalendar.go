@@ -0,0 +1,381 @@
+// Copyright 2025 The Nanoninja Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+package render
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+)
+
+// defaultProdID is the product identifier of calendars without one.
+const defaultProdID = "-//nanoninja//render//EN"
+
+// icalMaxLineOctets is the maximum length of a content line, excluding
+// the line break, before it is folded.
+const icalMaxLineOctets = 75
+
+// Calendar is an iCalendar object holding events, such as a meeting
+// invitation or a calendar feed.
+type Calendar struct {
+	// ProdID identifies the product that created the calendar. The default
+	// is "-//nanoninja//render//EN".
+	ProdID string
+
+	// Method is the iTIP method of the calendar, such as "REQUEST" for
+	// invitations or "CANCEL" for cancellations. It is also added to the
+	// content type. Calendar feeds usually leave it empty or use "PUBLISH".
+	Method string
+
+	// Name is the display name of the calendar, written as X-WR-CALNAME.
+	Name string
+
+	// Events are the events of the calendar.
+	Events []Event
+}
+
+// Event is an event of a calendar, written as a VEVENT component.
+//
+// Example:
+//
+//	paris, _ := time.LoadLocation("Europe/Paris")
+//	render.ICalendar().Render(w, render.Event{
+//	    UID:     "standup-42@example.com",
+//	    Summary: "Standup",
+//	    Start:   time.Date(2025, 3, 3, 9, 30, 0, 0, paris),
+//	    End:     time.Date(2025, 3, 3, 9, 45, 0, 0, paris),
+//	    RRule:   "FREQ=WEEKLY;BYDAY=MO,WE,FR",
+//	})
+type Event struct {
+	// UID uniquely and persistently identifies the event. It is required.
+	UID string
+
+	// Summary is the title of the event.
+	Summary string
+
+	// Description is the longer text of the event.
+	Description string
+
+	// Location is where the event takes place.
+	Location string
+
+	// URL links to a page about the event.
+	URL string
+
+	// Start is the start of the event. It is required. It is written in
+	// UTC, since no VTIMEZONE component is generated for its location.
+	Start time.Time
+
+	// End is the end of the event, written like Start when not zero.
+	End time.Time
+
+	// Stamp is the time the event was created or last changed, written as
+	// DTSTAMP. The default is the time of rendering.
+	Stamp time.Time
+
+	// RRule is the recurrence rule of the event, such as
+	// "FREQ=WEEKLY;BYDAY=MO".
+	RRule string
+
+	// Status is the status of the event, such as "CONFIRMED" or
+	// "CANCELLED".
+	Status string
+
+	// Sequence is the revision of the event, incremented on each change
+	// sent to attendees.
+	Sequence int
+
+	// Organizer is the organizer of the event.
+	Organizer *Attendee
+
+	// Attendees are the participants of the event.
+	Attendees []Attendee
+}
+
+// Attendee is a participant or the organizer of an event.
+type Attendee struct {
+	// Email is the address of the participant. It is required.
+	Email string
+
+	// Name is the display name of the participant, written as CN.
+	Name string
+
+	// Role is the participation role, such as "REQ-PARTICIPANT" or
+	// "OPT-PARTICIPANT".
+	Role string
+
+	// Status is the participation status, such as "NEEDS-ACTION" or
+	// "ACCEPTED", written as PARTSTAT.
+	Status string
+
+	// RSVP requests a reply from the participant.
+	RSVP bool
+}
+
+// icalendarRenderer implements the rendering of calendars as iCalendar.
+type icalendarRenderer struct{}
+
+// ICalendar creates a new renderer writing calendars and events as
+// iCalendar (RFC 5545) objects, such as .ics files and meeting invitations.
+func ICalendar() Renderer {
+	return &icalendarRenderer{}
+}
+
+// Render writes data as an iCalendar object using a background context.
+// Data must be a Calendar, an Event or a []Event, events being wrapped in
+// a calendar with default properties.
+func (r *icalendarRenderer) Render(w io.Writer, data any, opts ...func(*Options)) error {
+	return r.RenderContext(context.Background(), w, data, opts...)
+}
+
+// RenderContext writes data as an iCalendar object with context support.
+// Text values are escaped, and content lines longer than 75 octets are
+// folded without splitting UTF-8 characters. Lines always end with CRLF,
+// as required by RFC 5545, and the format options are ignored.
+// Events without UID or start, ending before they start, or holding
+// control characters in structured values return an error wrapping
+// ErrInvalidData before anything is written.
+// The content type is text/calendar, with the method of the calendar.
+func (r *icalendarRenderer) RenderContext(ctx context.Context, w io.Writer, data any, opts ...func(*Options)) error {
+	if err := CheckContext(ctx); err != nil {
+		return err
+	}
+	var cal Calendar
+	switch v := data.(type) {
+	case Calendar:
+		cal = v
+	case *Calendar:
+		if v == nil {
+			return ErrInvalidData
+		}
+		cal = *v
+	case Event:
+		cal.Events = []Event{v}
+	case *Event:
+		if v == nil {
+			return ErrInvalidData
+		}
+		cal.Events = []Event{*v}
+	case []Event:
+		cal.Events = v
+	default:
+		return ErrInvalidData
+	}
+
+	options := NewOptions().
+		Use(MimeCalendar(cal.Method)).
+		Use(ContextDefaults(ctx)).
+		Use(opts...)
+
+	if err := options.Err(); err != nil {
+		return err
+	}
+
+	e := &icalEncoder{now: time.Now()}
+	if err := e.encode(ctx, cal); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, e.b.String())
+	return err
+}
+
+// icalEncoder builds the content lines of a calendar.
+type icalEncoder struct {
+	b   strings.Builder
+	now time.Time
+}
+
+// encode writes cal, checking the context between events.
+func (e *icalEncoder) encode(ctx context.Context, cal Calendar) error {
+	prodID := cal.ProdID
+	if prodID == "" {
+		prodID = defaultProdID
+	}
+	if err := validateICalValue("METHOD", cal.Method); err != nil {
+		return err
+	}
+
+	e.line("BEGIN:VCALENDAR")
+	e.line("VERSION:2.0")
+	e.line("PRODID:" + icalText(prodID))
+	if cal.Method != "" {
+		e.line("METHOD:" + cal.Method)
+	}
+	if cal.Name != "" {
+		e.line("X-WR-CALNAME:" + icalText(cal.Name))
+	}
+	for i := range cal.Events {
+		if err := CheckContext(ctx); err != nil {
+			return err
+		}
+		if err := e.event(&cal.Events[i]); err != nil {
+			return err
+		}
+	}
+	e.line("END:VCALENDAR")
+	return nil
+}
+
+// event writes a VEVENT component.
+func (e *icalEncoder) event(ev *Event) error {
+	switch {
+	case ev.UID == "":
+		return fmt.Errorf("%w: event without UID", ErrInvalidData)
+	case ev.Start.IsZero():
+		return fmt.Errorf("%w: event %q without start", ErrInvalidData, ev.UID)
+	case !ev.End.IsZero() && ev.End.Before(ev.Start):
+		return fmt.Errorf("%w: event %q ends before it starts", ErrInvalidData, ev.UID)
+	}
+	if err := validateICalValue("RRULE", ev.RRule); err != nil {
+		return err
+	}
+	if err := validateICalValue("STATUS", ev.Status); err != nil {
+		return err
+	}
+	if err := validateICalValue("URL", ev.URL); err != nil {
+		return err
+	}
+
+	stamp := ev.Stamp
+	if stamp.IsZero() {
+		stamp = e.now
+	}
+
+	e.line("BEGIN:VEVENT")
+	e.line("UID:" + icalText(ev.UID))
+	e.line("DTSTAMP:" + icalUTC(stamp))
+	e.line("DTSTART:" + icalUTC(ev.Start))
+	if !ev.End.IsZero() {
+		e.line("DTEND:" + icalUTC(ev.End))
+	}
+	if ev.RRule != "" {
+		e.line("RRULE:" + ev.RRule)
+	}
+	e.optional("SUMMARY", ev.Summary)
+	e.optional("DESCRIPTION", ev.Description)
+	e.optional("LOCATION", ev.Location)
+	if ev.URL != "" {
+		e.line("URL:" + ev.URL)
+	}
+	if ev.Status != "" {
+		e.line("STATUS:" + ev.Status)
+	}
+	if ev.Sequence > 0 {
+		e.line("SEQUENCE:" + strconv.Itoa(ev.Sequence))
+	}
+	if ev.Organizer != nil {
+		if err := e.attendee("ORGANIZER", *ev.Organizer); err != nil {
+			return err
+		}
+	}
+	for _, a := range ev.Attendees {
+		if err := e.attendee("ATTENDEE", a); err != nil {
+			return err
+		}
+	}
+	e.line("END:VEVENT")
+	return nil
+}
+
+// attendee writes an ORGANIZER or ATTENDEE property.
+func (e *icalEncoder) attendee(name string, a Attendee) error {
+	if a.Email == "" {
+		return fmt.Errorf("%w: %s without email", ErrInvalidData, strings.ToLower(name))
+	}
+	if err := validateICalValue(name, a.Email+a.Role+a.Status); err != nil {
+		return err
+	}
+	line := name
+	if a.Name != "" {
+		line += ";CN=" + icalParam(a.Name)
+	}
+	if a.Role != "" {
+		line += ";ROLE=" + icalParam(a.Role)
+	}
+	if a.Status != "" {
+		line += ";PARTSTAT=" + icalParam(a.Status)
+	}
+	if a.RSVP {
+		line += ";RSVP=TRUE"
+	}
+	e.line(line + ":mailto:" + a.Email)
+	return nil
+}
+
+// optional writes a text property when its value is not empty.
+func (e *icalEncoder) optional(name, value string) {
+	if value != "" {
+		e.line(name + ":" + icalText(value))
+	}
+}
+
+// line writes a content line.
+func (e *icalEncoder) line(s string) {
+	writeContentLine(&e.b, s)
+}
+
+// writeContentLine writes a content line of iCalendar and vCard, folded
+// after 75 octets with a CRLF followed by a space, and ended with a CRLF.
+// Lines are only folded between UTF-8 characters.
+func writeContentLine(b *strings.Builder, s string) {
+	limit := icalMaxLineOctets
+	for len(s) > limit {
+		cut := limit
+		for cut > 0 && !utf8.RuneStart(s[cut]) {
+			cut--
+		}
+		b.WriteString(s[:cut] + "\r\n ")
+		s = s[cut:]
+		// Continuation lines start with a space, counted in their length
+		limit = icalMaxLineOctets - 1
+	}
+	b.WriteString(s + "\r\n")
+}
+
+// validateICalValue checks that a structured value holds no control
+// characters, which would break the content line.
+func validateICalValue(name, value string) error {
+	if strings.IndexFunc(value, unicode.IsControl) >= 0 {
+		return fmt.Errorf("%w: control character in %s %q", ErrInvalidData, name, value)
+	}
+	return nil
+}
+
+// icalTextReplacer escapes the characters of TEXT values of iCalendar and
+// vCard.
+var icalTextReplacer = strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\r\n", `\n`, "\n", `\n`, "\r", `\n`)
+
+// icalText escapes a TEXT value.
+func icalText(s string) string {
+	return icalTextReplacer.Replace(s)
+}
+
+// icalParam formats a parameter value, quoting it when it holds
+// separators. Double quotes cannot be escaped and are replaced by single
+// quotes, and control characters are removed.
+func icalParam(s string) string {
+	s = strings.Map(func(r rune) rune {
+		switch {
+		case r == '"':
+			return '\''
+		case unicode.IsControl(r):
+			return -1
+		}
+		return r
+	}, s)
+	if strings.ContainsAny(s, ";:,") {
+		return `"` + s + `"`
+	}
+	return s
+}
+
+// icalUTC formats t as a UTC date-time.
+func icalUTC(t time.Time) string {
+	return t.UTC().Format("20060102T150405Z")
+}
@@ -0,0 +1,170 @@
+// Copyright 2025 The Nanoninja Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+package render
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf8"
+
+	"github.com/nanoninja/assert"
+)
+
+var (
+	_ Renderer = (*icalendarRenderer)(nil)
+	_ Renderer = ICalendar()
+)
+
+// icalStamp is the DTSTAMP of test events.
+var icalStamp = time.Date(2025, 2, 1, 12, 0, 0, 0, time.UTC)
+
+func TestICalendarRenderer(t *testing.T) {
+	t.Run("RendersInvitations", func(t *testing.T) {
+		var (
+			w       bytes.Buffer
+			options *Options
+		)
+		cal := Calendar{
+			Method: "REQUEST",
+			Events: []Event{{
+				UID:         "review-1@example.com",
+				Summary:     "Review; budget, Q1",
+				Description: "Agenda:\n1. Numbers\n2. C:\\reports",
+				Start:       time.Date(2025, 3, 3, 8, 30, 0, 0, time.UTC),
+				End:         time.Date(2025, 3, 3, 9, 0, 0, 0, time.UTC),
+				Stamp:       icalStamp,
+				Sequence:    2,
+				Organizer:   &Attendee{Email: "alice@example.com", Name: "Alice"},
+				Attendees: []Attendee{
+					{Email: "bob@example.com", Name: `Doe, "Bob"`, Role: "REQ-PARTICIPANT", Status: "NEEDS-ACTION", RSVP: true},
+				},
+			}},
+		}
+
+		err := ICalendar().Render(&w, cal, CaptureOptions(&options))
+
+		assert.Nil(t, err)
+		assert.Equals(t, options.ContentType(), "text/calendar; charset=utf-8; method=REQUEST")
+		assert.Equals(t, w.String(), "BEGIN:VCALENDAR\r\n"+
+			"VERSION:2.0\r\n"+
+			"PRODID:-//nanoninja//render//EN\r\n"+
+			"METHOD:REQUEST\r\n"+
+			"BEGIN:VEVENT\r\n"+
+			"UID:review-1@example.com\r\n"+
+			"DTSTAMP:20250201T120000Z\r\n"+
+			"DTSTART:20250303T083000Z\r\n"+
+			"DTEND:20250303T090000Z\r\n"+
+			"SUMMARY:Review\\; budget\\, Q1\r\n"+
+			"DESCRIPTION:Agenda:\\n1. Numbers\\n2. C:\\\\reports\r\n"+
+			"SEQUENCE:2\r\n"+
+			"ORGANIZER;CN=Alice:mailto:alice@example.com\r\n"+
+			"ATTENDEE;CN=\"Doe, 'Bob'\";ROLE=REQ-PARTICIPANT;PARTSTAT=NEEDS-ACTION;RSVP=TR\r\n"+
+			" UE:mailto:bob@example.com\r\n"+
+			"END:VEVENT\r\n"+
+			"END:VCALENDAR\r\n")
+	})
+
+	t.Run("WritesTimesInUTC", func(t *testing.T) {
+		var w bytes.Buffer
+		paris := time.FixedZone("Europe/Paris", 3600)
+		event := Event{
+			UID:   "standup@example.com",
+			Start: time.Date(2025, 3, 3, 9, 30, 0, 0, paris),
+			End:   time.Date(2025, 3, 3, 9, 45, 0, 0, paris),
+			Stamp: icalStamp,
+		}
+
+		err := ICalendar().Render(&w, &event)
+
+		assert.Nil(t, err)
+		assert.StringContains(t, w.String(), "\r\nDTSTART:20250303T083000Z\r\n")
+		assert.StringContains(t, w.String(), "\r\nDTEND:20250303T084500Z\r\n")
+	})
+
+	t.Run("WritesLocalTimesInUTC", func(t *testing.T) {
+		var w bytes.Buffer
+		start := time.Date(2025, 3, 3, 9, 30, 0, 0, time.Local)
+
+		err := ICalendar().Render(&w, []Event{{UID: "a", Start: start, Stamp: icalStamp}})
+
+		assert.Nil(t, err)
+		assert.StringContains(t, w.String(), "\r\nDTSTART:"+start.UTC().Format("20060102T150405Z")+"\r\n")
+	})
+
+	t.Run("FoldsLongLines", func(t *testing.T) {
+		var w bytes.Buffer
+		summary := strings.Repeat("é", 100)
+
+		err := ICalendar().Render(&w, Event{UID: "a", Summary: summary, Start: icalStamp, Stamp: icalStamp})
+
+		assert.Nil(t, err)
+		for _, line := range strings.Split(w.String(), "\r\n") {
+			assert.True(t, len(line) <= 75)
+			assert.True(t, utf8.ValidString(line))
+		}
+		unfolded := strings.ReplaceAll(w.String(), "\r\n ", "")
+		assert.StringContains(t, unfolded, "\r\nSUMMARY:"+summary+"\r\n")
+	})
+
+	t.Run("UsesCalendarProperties", func(t *testing.T) {
+		var w bytes.Buffer
+
+		err := ICalendar().Render(&w, &Calendar{ProdID: "-//Example//Feed//EN", Name: "Team, events"})
+
+		assert.Nil(t, err)
+		assert.Equals(t, w.String(), "BEGIN:VCALENDAR\r\nVERSION:2.0\r\nPRODID:-//Example//Feed//EN\r\n"+
+			"X-WR-CALNAME:Team\\, events\r\nEND:VCALENDAR\r\n")
+	})
+
+	t.Run("IgnoresFormatOptions", func(t *testing.T) {
+		var w bytes.Buffer
+
+		err := ICalendar().Render(&w, Calendar{}, Format(Prefix("> "), LineEnding("\n")))
+
+		assert.Nil(t, err)
+		assert.HasPrefix(t, w.String(), "BEGIN:VCALENDAR\r\n")
+	})
+
+	t.Run("RejectsInvalidEvents", func(t *testing.T) {
+		start := time.Date(2025, 3, 3, 9, 0, 0, 0, time.UTC)
+		for name, event := range map[string]Event{
+			"MissingUID":     {Start: start},
+			"MissingStart":   {UID: "a"},
+			"EndBeforeStart": {UID: "a", Start: start, End: start.Add(-time.Hour)},
+			"BrokenRRule":    {UID: "a", Start: start, RRule: "FREQ=DAILY\r\nX:1"},
+			"NoAttendeeMail": {UID: "a", Start: start, Attendees: []Attendee{{Name: "Bob"}}},
+		} {
+			t.Run(name, func(t *testing.T) {
+				var w bytes.Buffer
+
+				err := ICalendar().Render(&w, event)
+
+				assert.ErrorIs(t, err, ErrInvalidData)
+				assert.Equals(t, w.Len(), 0)
+			})
+		}
+	})
+
+	t.Run("RejectsInvalidData", func(t *testing.T) {
+		var w bytes.Buffer
+
+		err := ICalendar().Render(&w, "BEGIN:VCALENDAR")
+
+		assert.ErrorIs(t, err, ErrInvalidData)
+	})
+
+	t.Run("ReturnsContextError", func(t *testing.T) {
+		var w bytes.Buffer
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		err := ICalendar().RenderContext(ctx, &w, Calendar{})
+
+		assert.ErrorIs(t, err, context.Canceled)
+		assert.Equals(t, w.Len(), 0)
+	})
+}
@@ -0,0 +1,137 @@
+// Copyright 2025 The Nanoninja Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+package render
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"unicode"
+)
+
+// INIConfig defines configuration options for INI rendering.
+type INIConfig struct {
+	// Separator is written between keys and values. The default is " = ".
+	Separator string
+}
+
+// iniRenderer implements the rendering of structs and maps as INI files.
+type iniRenderer struct {
+	config INIConfig
+}
+
+// INI creates a new INI renderer with ";" comments and " = " separators.
+// This is the recommended constructor for most use cases.
+func INI() Renderer {
+	return NewINI(INIConfig{})
+}
+
+// NewINI creates an INI renderer with custom configuration. Empty fields
+// use the defaults of INI.
+func NewINI(c INIConfig) Renderer {
+	if c.Separator == "" {
+		c.Separator = " = "
+	}
+	return &iniRenderer{config: c}
+}
+
+// Render writes data as an INI file using a background context.
+// Data must be a struct or a map. Scalar fields and entries are written as
+// keys, before any section, while nested structs and maps become sections,
+// named with dots when nested further, such as [database.pool].
+// Struct fields are named by the "ini" struct tag when set, skipped when
+// the tag is "-" and when empty with the "omitempty" tag option. Maps are
+// sorted by key, and slices of scalars are joined by commas, items holding
+// a comma returning an error wrapping ErrInvalidData.
+func (r *iniRenderer) Render(w io.Writer, data any, opts ...func(*Options)) error {
+	return r.RenderContext(context.Background(), w, data, opts...)
+}
+
+// RenderContext writes data as an INI file with context support.
+// Values are quoted when they have leading or trailing spaces, comment
+// markers, quotes or control characters, escaping backslashes, quotes and
+// line breaks. Keys and section names that cannot be read back, such as
+// names holding "=", brackets or line breaks, return an error wrapping
+// ErrInvalidKey before anything is written.
+// The header comment set with HeaderComment is written first, with the
+// marker set with Comment, ";" by default. Markers other than ";" and "#"
+// return an error wrapping ErrInvalidParam. The content type is text/plain
+// by default.
+func (r *iniRenderer) RenderContext(ctx context.Context, w io.Writer, data any, opts ...func(*Options)) error {
+	if err := CheckContext(ctx); err != nil {
+		return err
+	}
+	options := NewOptions().
+		Use(MimeTextPlain()).
+		Use(ContextDefaults(ctx)).
+		Use(opts...)
+
+	if err := options.Err(); err != nil {
+		return err
+	}
+
+	marker, err := headerMarker(options, ";", ";", "#")
+	if err != nil {
+		return err
+	}
+
+	walker := configWalker{tag: "ini", fieldName: identityName}
+	sections, err := walker.walk(data)
+	if err != nil {
+		return err
+	}
+
+	var b strings.Builder
+	writeHeaderComment(&b, options, marker, identityName)
+	for i, sec := range sections {
+		if i > 0 {
+			name := strings.Join(sec.path, ".")
+			if err := validateININame(name, "[]"); err != nil {
+				return err
+			}
+			if i > 1 || len(sections[0].entries) > 0 {
+				b.WriteByte('\n')
+			}
+			b.WriteString("[" + name + "]\n")
+		}
+		for _, entry := range sec.entries {
+			if err := validateININame(entry.key, "=:;#[]"); err != nil {
+				return err
+			}
+			b.WriteString(entry.key + r.config.Separator + iniValue(entry.value) + "\n")
+		}
+	}
+
+	out, err := OutputWriter(w, options)
+	if err != nil {
+		return err
+	}
+	if _, err := io.WriteString(out, b.String()); err != nil {
+		return err
+	}
+	return out.Close()
+}
+
+// validateININame checks that a key or section name is not empty, has no
+// surrounding spaces, and holds neither control characters nor any of the
+// reserved characters.
+func validateININame(name, reserved string) error {
+	if name == "" || strings.TrimSpace(name) != name ||
+		strings.ContainsAny(name, reserved) || strings.IndexFunc(name, unicode.IsControl) >= 0 {
+		return fmt.Errorf("%w: INI name %q", ErrInvalidKey, name)
+	}
+	return nil
+}
+
+// iniValueReplacer escapes the characters of quoted INI values.
+var iniValueReplacer = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`, "\r", `\r`, "\t", `\t`)
+
+// iniValue quotes a value when it would not be read back as-is.
+func iniValue(s string) string {
+	if strings.TrimSpace(s) == s && !strings.ContainsAny(s, `;#"`) && strings.IndexFunc(s, unicode.IsControl) < 0 {
+		return s
+	}
+	return `"` + iniValueReplacer.Replace(s) + `"`
+}
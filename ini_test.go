@@ -0,0 +1,162 @@
+// Copyright 2025 The Nanoninja Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+package render
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/nanoninja/assert"
+)
+
+var (
+	_ Renderer = (*iniRenderer)(nil)
+	_ Renderer = INI()
+)
+
+func TestINIRenderer(t *testing.T) {
+	t.Run("RendersStructsWithSections", func(t *testing.T) {
+		var w bytes.Buffer
+
+		err := INI().Render(&w, newConfigServer())
+
+		assert.Nil(t, err)
+		assert.Equals(t, w.String(), ""+
+			"name = api\n"+
+			"port = 8080\n"+
+			"hosts = a.local,b.local\n"+
+			"\n"+
+			"[database]\n"+
+			"url = postgres://db/app\n"+
+			"\n"+
+			"[database.pool]\n"+
+			"max_conns = 10\n")
+	})
+
+	t.Run("RendersSortedMaps", func(t *testing.T) {
+		var w bytes.Buffer
+		data := map[string]any{
+			"server": map[string]any{"port": 80, "host": "web"},
+			"debug":  false,
+		}
+
+		err := NewINI(INIConfig{Separator: "="}).Render(&w, data)
+
+		assert.Nil(t, err)
+		assert.Equals(t, w.String(), "debug=false\n\n[server]\nhost=web\nport=80\n")
+	})
+
+	t.Run("QuotesValues", func(t *testing.T) {
+		var w bytes.Buffer
+		data := map[string]string{
+			"comment": "a; b",
+			"hash":    "#1",
+			"padded":  " x ",
+			"path":    `C:\data`,
+			"quote":   `say "hi"`,
+			"lines":   "a\nb",
+			"empty":   "",
+		}
+
+		err := INI().Render(&w, data)
+
+		assert.Nil(t, err)
+		assert.Equals(t, w.String(), ""+
+			`comment = "a; b"`+"\n"+
+			"empty = \n"+
+			`hash = "#1"`+"\n"+
+			`lines = "a\nb"`+"\n"+
+			`padded = " x "`+"\n"+
+			`path = C:\data`+"\n"+
+			`quote = "say \"hi\""`+"\n")
+	})
+
+	t.Run("WritesHeaderComment", func(t *testing.T) {
+		var w bytes.Buffer
+
+		err := INI().Render(&w, map[string]int{"a": 1}, Format(Comment("#"), HeaderComment("Generated file\n\nDo not edit")))
+
+		assert.Nil(t, err)
+		assert.Equals(t, w.String(), "# Generated file\n#\n# Do not edit\n\na = 1\n")
+	})
+
+	t.Run("AppliesLineEndingToHeaderComment", func(t *testing.T) {
+		var w bytes.Buffer
+		data := map[string]any{"s": map[string]int{"a": 1}}
+
+		err := INI().Render(&w, data, Format(Comment(";"), HeaderComment("example"), LineEnding("\r\n")))
+
+		assert.Nil(t, err)
+		assert.Equals(t, w.String(), "; example\r\n\r\n[s]\r\na = 1\r\n")
+	})
+
+	t.Run("CommentsEveryLineOfHeaderComment", func(t *testing.T) {
+		var w bytes.Buffer
+
+		err := INI().Render(&w, map[string]int{"a": 1}, Format(HeaderComment("hi\r[evil]\rx=1\r\nend")))
+
+		assert.Nil(t, err)
+		assert.Equals(t, w.String(), "; hi\n; [evil]\n; x=1\n; end\n\na = 1\n")
+	})
+
+	t.Run("KeepsExplicitPrefix", func(t *testing.T) {
+		var w bytes.Buffer
+
+		err := INI().Render(&w, map[string]int{"a": 1}, Format(Prefix("; ")))
+
+		assert.Nil(t, err)
+		assert.Equals(t, w.String(), "; a = 1\n")
+	})
+
+	t.Run("RejectsInvalidCommentMarker", func(t *testing.T) {
+		var w bytes.Buffer
+
+		err := INI().Render(&w, map[string]int{"a": 1}, Format(Comment("//")))
+
+		assert.ErrorIs(t, err, ErrInvalidParam)
+		assert.Equals(t, w.Len(), 0)
+	})
+
+	t.Run("RejectsCommasInListItems", func(t *testing.T) {
+		var w bytes.Buffer
+
+		err := INI().Render(&w, map[string][]string{"hosts": {"a,b", "c"}})
+
+		assert.ErrorIs(t, err, ErrInvalidData)
+		assert.Equals(t, w.Len(), 0)
+	})
+
+	t.Run("RejectsInvalidKeys", func(t *testing.T) {
+		tests := map[string]any{
+			"Equals":      map[string]int{"a=b": 1},
+			"Bracket":     map[string]int{"[a]": 1},
+			"Comment":     map[string]int{";a": 1},
+			"Empty":       map[string]int{"": 1},
+			"Padded":      map[string]int{" a": 1},
+			"Newline":     map[string]int{"a\nb": 1},
+			"SectionName": map[string]any{"a]b": map[string]int{"c": 1}},
+		}
+		for name, data := range tests {
+			t.Run(name, func(t *testing.T) {
+				var w bytes.Buffer
+
+				err := INI().Render(&w, data)
+
+				assert.ErrorIs(t, err, ErrInvalidKey)
+				assert.Equals(t, w.Len(), 0)
+			})
+		}
+	})
+
+	t.Run("RespectsContextCancellation", func(t *testing.T) {
+		var w bytes.Buffer
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		err := INI().RenderContext(ctx, &w, map[string]int{"a": 1})
+
+		assert.ErrorIs(t, err, context.Canceled)
+	})
+}
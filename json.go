@@ -16,7 +16,8 @@ type JSONConfig struct {
 	// Controls HTML character escaping
 	EscapeHTML bool
 
-	// Custom prefix for JSON output
+	// Custom prefix for each line of JSON output.
+	// It is overridden by the Prefix format option.
 	Prefix string
 
 	// Custom indentation for JSON output
@@ -58,36 +59,49 @@ func (r jsonRenderer) Render(w io.Writer, data any, opts ...func(*Options)) erro
 // It handles:
 // - JSONP wrapping if padding is configured
 // - HTML escaping based on configuration
-// - Pretty printing with customizable indent and prefix
+// - Pretty printing with customizable indent
+// - Prefix and line ending applied to every line
 // - Content type setting to application/json
+// - Transcoding to the charset declared by the content type
 func (r *jsonRenderer) RenderContext(ctx context.Context, w io.Writer, data any, opts ...func(*Options)) error {
 	if err := CheckContext(ctx); err != nil {
 		return err
 	}
 	options := NewOptions().
-		Use(MimeJSON()).
+		Use(MimeJSON(), Format(Prefix(r.config.Prefix))).
+		Use(ContextDefaults(ctx)).
 		Use(opts...)
 
+	if err := options.Err(); err != nil {
+		return err
+	}
+
+	tw, err := OutputWriter(w, options)
+	if err != nil {
+		return err
+	}
 	if r.config.Padding != "" {
-		if _, err := fmt.Fprintf(w, "%s(", r.config.Padding); err != nil {
+		if _, err := fmt.Fprintf(tw, "%s(", r.config.Padding); err != nil {
 			return err
 		}
-		defer fmt.Fprintf(w, ")")
 	}
-	encoder := json.NewEncoder(w)
+	encoder := json.NewEncoder(tw)
 	encoder.SetEscapeHTML(r.config.EscapeHTML)
 
 	if options.format.pretty {
-		prefix := options.format.prefix
 		indent := r.config.Indent
-
-		if prefix == "" {
-			prefix = r.config.Prefix
-		}
 		if options.format.indent != "" {
 			indent = options.format.indent
 		}
-		encoder.SetIndent(prefix, indent)
+		encoder.SetIndent("", indent)
+	}
+	if err := encoder.Encode(data); err != nil {
+		return err
+	}
+	if r.config.Padding != "" {
+		if _, err := io.WriteString(tw, ")"); err != nil {
+			return err
+		}
 	}
-	return encoder.Encode(data)
+	return tw.Close()
 }
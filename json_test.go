@@ -24,7 +24,7 @@ const jsonPrettyTest = `{
   "message": "JSON render test"
 }`
 
-const jsonPrefixTest = `{
+const jsonPrefixTest = `>>{
 >>  "message": "JSON prefix test"
 >>}`
 
@@ -159,4 +159,21 @@ func TestJSONRenderer(t *testing.T) {
 
 		assert.NotNil(t, err)
 	})
+	t.Run("FormatPrefixOverridesConfigPrefix", func(t *testing.T) {
+		var w bytes.Buffer
+
+		err := NewJSON(JSONConfig{Prefix: "config "}).Render(&w, []int{1}, Format(Prefix("option ")))
+
+		assert.Nil(t, err)
+		assert.Equals(t, w.String(), "option [1]\n")
+	})
+
+	t.Run("AppliesLineEndingToPrettyOutput", func(t *testing.T) {
+		var w bytes.Buffer
+
+		err := JSON().Render(&w, []int{1}, Format(Pretty(), LineEnding("\r\n")))
+
+		assert.Nil(t, err)
+		assert.Equals(t, w.String(), "[\r\n  1\r\n]\r\n")
+	})
 }
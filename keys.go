@@ -0,0 +1,229 @@
+// Copyright 2025 The Nanoninja Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+package render
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"sync"
+	"time"
+	"unicode/utf8"
+)
+
+// Built-in keys used by the renderers of this package.
+var (
+	// SeparatorKey holds the CSV field separator. The default is a comma.
+	SeparatorKey = NewKey("separator", ',')
+
+	// RangeKey holds the Range header of the request served by Binary.
+	RangeKey = NewKey("range", "")
+
+	// IfRangeKey holds the If-Range header of the request served by Binary.
+	IfRangeKey = NewKey("if-range", "")
+)
+
+// keyRegistry records the type of every key created with NewKey,
+// so that values can be validated in strict mode.
+var keyRegistry = struct {
+	sync.RWMutex
+	types map[string]reflect.Type
+}{types: make(map[string]reflect.Type)}
+
+// Key is a typed option key. Values are set with Set and read with Get,
+// giving renderers type-safe parameters in place of the string map
+// managed by Param.
+//
+// Keys are identified by their name, which should be unique and is
+// recommended to be prefixed by the package name for third-party renderers.
+type Key[T any] struct {
+	name string
+	def  T
+}
+
+// NewKey creates a key with the given name and default value, returned
+// by Get when the key is not set. It panics if a key with the same name
+// was already created with a different type, in the same way as
+// flag.Var panics on redefinition. Keys are usually created as package
+// level variables.
+//
+// Example:
+//
+//	var Columns = render.NewKey("table.columns", 80)
+//
+//	renderer.Render(w, data, render.Set(Columns, 120))
+//
+//	// Inside the renderer
+//	width := Columns.Get(options)
+func NewKey[T any](name string, def T) Key[T] {
+	typ := reflect.TypeOf((*T)(nil)).Elem()
+
+	keyRegistry.Lock()
+	defer keyRegistry.Unlock()
+
+	if registered, ok := keyRegistry.types[name]; ok && registered != typ {
+		panic(fmt.Sprintf("render: key %q redefined as %v, previously %v", name, typ, registered))
+	}
+	keyRegistry.types[name] = typ
+	return Key[T]{name: name, def: def}
+}
+
+// Name returns the name of the key.
+func (k Key[T]) Name() string {
+	return k.name
+}
+
+// Default returns the value returned by Get when the key is not set.
+func (k Key[T]) Default() T {
+	return k.def
+}
+
+// Get returns the value of the key in o, or its default value.
+// A string parameter with the same name, set with Param, is used when no
+// typed value is set and it can be parsed as T. Outside strict mode, keys
+// of kind int32, such as SeparatorKey, use the first character of the
+// parameter, as Separator does.
+func (k Key[T]) Get(o *Options) T {
+	v, _ := k.Lookup(o)
+	return v
+}
+
+// Lookup returns the value of the key in o and whether it is set.
+// It returns the default value when the key is not set.
+func (k Key[T]) Lookup(o *Options) (T, bool) {
+	if v, ok := o.values[k.name]; ok {
+		if t, ok := v.(T); ok {
+			return t, true
+		}
+		if v == nil {
+			// A nil value set for an interface type
+			var zero T
+			return zero, true
+		}
+	}
+	if s, ok := o.params[k.name]; ok {
+		if v, err := parseParam(s, reflect.TypeOf((*T)(nil)).Elem(), o.strict); err == nil {
+			return v.(T), true
+		}
+	}
+	return k.def, false
+}
+
+// String returns the name of the key.
+func (k Key[T]) String() string {
+	return k.name
+}
+
+// Set returns an option function that sets the value of a key.
+//
+// Example:
+//
+//	renderer.Render(w, records, render.Set(render.SeparatorKey, ';'))
+func Set[T any](key Key[T], value T) func(*Options) {
+	return func(o *Options) {
+		if o.values == nil {
+			o.values = make(map[string]any)
+		}
+		o.values[key.name] = value
+	}
+}
+
+// Strict returns an option function enabling strict validation of
+// parameters. In strict mode, Options.Err returns an error wrapping
+// ErrInvalidParam when a parameter set with Param does not match a key
+// created with NewKey, or cannot be parsed as the type of its key.
+//
+// Example:
+//
+//	// Fails: "separator" must hold a single character
+//	render.CSV().Render(w, records, render.Strict(), render.Param("separator", ";;"))
+func Strict() func(*Options) {
+	return func(o *Options) { o.strict = true }
+}
+
+// validateParams checks the typed values and string parameters against
+// the registered keys.
+func (o *Options) validateParams() error {
+	keyRegistry.RLock()
+	defer keyRegistry.RUnlock()
+
+	for _, name := range sortedKeys(o.values) {
+		if typ, ok := keyRegistry.types[name]; !ok || !assignable(o.values[name], typ) {
+			return fmt.Errorf("unknown key %q of type %T: %w", name, o.values[name], ErrInvalidParam)
+		}
+	}
+	for _, name := range sortedKeys(o.params) {
+		typ, ok := keyRegistry.types[name]
+		if !ok {
+			return fmt.Errorf("unknown parameter %q: %w", name, ErrInvalidParam)
+		}
+		if _, err := parseParam(o.params[name], typ, true); err != nil {
+			return fmt.Errorf("parameter %q: %v: %w", name, err, ErrInvalidParam)
+		}
+	}
+	return nil
+}
+
+// assignable reports whether v can be the value of a key of type typ.
+// Keys of interface types hold values of their dynamic type, or nil.
+func assignable(v any, typ reflect.Type) bool {
+	if v == nil {
+		switch typ.Kind() {
+		case reflect.Interface, reflect.Pointer, reflect.Map, reflect.Slice, reflect.Func, reflect.Chan:
+			return true
+		}
+		return false
+	}
+	return reflect.TypeOf(v).AssignableTo(typ)
+}
+
+// durationType is the reflect type of time.Duration, which is parsed
+// with time.ParseDuration rather than as an integer.
+var durationType = reflect.TypeOf(time.Duration(0))
+
+// parseParam converts a string parameter to a value of type typ, or of a
+// type with the same kind. Since rune cannot be told apart from int32,
+// types of kind int32 are parsed as a character: the first one of s, or
+// its only one when exact is set, as in strict mode.
+func parseParam(s string, typ reflect.Type, exact bool) (any, error) {
+	var (
+		v   any
+		err error
+	)
+	switch {
+	case typ == durationType:
+		v, err = time.ParseDuration(s)
+	default:
+		switch typ.Kind() {
+		case reflect.Int32:
+			r, size := utf8.DecodeRuneInString(s)
+			if r == utf8.RuneError || exact && size != len(s) {
+				return nil, fmt.Errorf("%q is not a single character", s)
+			}
+			v = r
+		case reflect.String:
+			v = s
+		case reflect.Bool:
+			v, err = strconv.ParseBool(s)
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int64:
+			var n int64
+			n, err = strconv.ParseInt(s, 10, typ.Bits())
+			v = n
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			var n uint64
+			n, err = strconv.ParseUint(s, 10, typ.Bits())
+			v = n
+		case reflect.Float32, reflect.Float64:
+			var n float64
+			n, err = strconv.ParseFloat(s, typ.Bits())
+			v = n
+		default:
+			return nil, fmt.Errorf("%v cannot be set from a string", typ)
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	return reflect.ValueOf(v).Convert(typ).Interface(), nil
+}
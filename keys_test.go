@@ -0,0 +1,163 @@
+// Copyright 2025 The Nanoninja Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+package render
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/nanoninja/assert"
+)
+
+var (
+	testLimitKey   = NewKey("render_test.limit", 10)
+	testDelayKey   = NewKey("render_test.delay", time.Second)
+	testEnabledKey = NewKey("render_test.enabled", false)
+	testCauseKey   = NewKey[error]("render_test.cause", nil)
+	testLevelKey   = NewKey("render_test.level", testLevel(0))
+	testMarkKey    = NewKey("render_test.mark", testMark('-'))
+)
+
+type (
+	testLevel uint8
+	testMark  int32
+)
+
+func TestKey(t *testing.T) {
+	t.Run("GetReturnsDefault", func(t *testing.T) {
+		opts := NewOptions()
+
+		limit, ok := testLimitKey.Lookup(opts)
+
+		assert.Equals(t, limit, 10)
+		assert.False(t, ok)
+		assert.Equals(t, testLimitKey.Get(opts), testLimitKey.Default())
+		assert.Equals(t, testLimitKey.Name(), "render_test.limit")
+	})
+
+	t.Run("SetStoresTypedValue", func(t *testing.T) {
+		opts := NewOptions().Use(Set(testLimitKey, 42), Set(testDelayKey, time.Minute))
+
+		assert.Equals(t, testLimitKey.Get(opts), 42)
+		assert.Equals(t, testDelayKey.Get(opts), time.Minute)
+	})
+
+	t.Run("GetParsesStringParameters", func(t *testing.T) {
+		opts := NewOptions().Use(
+			Param("render_test.limit", "7"),
+			Param("render_test.delay", "250ms"),
+			Param("render_test.enabled", "true"),
+			Param("separator", "|"),
+		)
+
+		assert.Equals(t, testLimitKey.Get(opts), 7)
+		assert.Equals(t, testDelayKey.Get(opts), 250*time.Millisecond)
+		assert.True(t, testEnabledKey.Get(opts))
+		assert.Equals(t, SeparatorKey.Get(opts), '|')
+	})
+
+	t.Run("ParsesNamedTypesByKind", func(t *testing.T) {
+		opts := NewOptions().Use(Param("render_test.level", "3"), Param("render_test.mark", "*"))
+
+		assert.Equals(t, testLevelKey.Get(opts), testLevel(3))
+		assert.Equals(t, testMarkKey.Get(opts), testMark('*'))
+	})
+
+	t.Run("UsesFirstCharacterOutsideStrictMode", func(t *testing.T) {
+		var w bytes.Buffer
+
+		err := CSV().Render(&w, [][]string{{"a", "b"}}, Param("separator", ";;"))
+
+		assert.Nil(t, err)
+		assert.Equals(t, w.String(), "a;b\n")
+	})
+
+	t.Run("TypedValueTakesPrecedence", func(t *testing.T) {
+		opts := NewOptions().Use(Set(testLimitKey, 1), Param("render_test.limit", "2"))
+
+		assert.Equals(t, testLimitKey.Get(opts), 1)
+	})
+
+	t.Run("IgnoresUnparsableParameter", func(t *testing.T) {
+		opts := NewOptions().Use(Param("render_test.limit", "many"))
+
+		assert.Equals(t, testLimitKey.Get(opts), 10)
+		assert.Nil(t, opts.Err())
+	})
+
+	t.Run("NewKeyPanicsOnTypeRedefinition", func(t *testing.T) {
+		assert.Equals(t, NewKey("render_test.limit", 3).Get(NewOptions()), 3)
+		assert.Panics(t, func() { NewKey("render_test.limit", "ten") },
+			`render: key "render_test.limit" redefined as string, previously int`)
+	})
+
+	t.Run("StringListsTypedValues", func(t *testing.T) {
+		opts := NewOptions().Use(Set(testLimitKey, 5), Separator(";"), Param("extra", "x"))
+
+		assert.True(t, strings.HasSuffix(opts.String(), `Parameters:
+  extra: x
+  render_test.limit: 5
+  separator: ';'
+`))
+	})
+}
+
+func TestStrict(t *testing.T) {
+	t.Run("AcceptsRegisteredParameters", func(t *testing.T) {
+		opts := NewOptions().Use(Strict(), Param("render_test.limit", "3"), Set(testDelayKey, time.Second))
+
+		assert.Nil(t, opts.Err())
+	})
+
+	t.Run("RejectsUnknownParameter", func(t *testing.T) {
+		opts := NewOptions().Use(Strict(), Param("seperator", ";"))
+
+		assert.ErrorIs(t, opts.Err(), ErrInvalidParam)
+		assert.StringContains(t, opts.Err().Error(), `"seperator"`)
+	})
+
+	t.Run("RejectsMistypedParameter", func(t *testing.T) {
+		opts := NewOptions().Use(Param("render_test.limit", "many"), Strict())
+
+		assert.ErrorIs(t, opts.Err(), ErrInvalidParam)
+	})
+
+	t.Run("AcceptsInterfaceKeys", func(t *testing.T) {
+		cause := errors.New("cause")
+
+		for _, value := range []error{cause, nil} {
+			opts := NewOptions().Use(Strict(), Set(testCauseKey, value))
+
+			got, ok := testCauseKey.Lookup(opts)
+
+			assert.Nil(t, opts.Err())
+			assert.True(t, ok)
+			assert.Equals(t, got, value)
+		}
+	})
+
+	t.Run("RejectsUnregisteredKey", func(t *testing.T) {
+		opts := NewOptions().Use(Strict(), Set(Key[int]{name: "render_test.unknown"}, 1))
+
+		assert.ErrorIs(t, opts.Err(), ErrInvalidParam)
+	})
+
+	t.Run("AbortsRendering", func(t *testing.T) {
+		var w bytes.Buffer
+
+		err := CSV().Render(&w, [][]string{{"a", "b"}}, Strict(), Param("separator", ";;"))
+
+		assert.ErrorIs(t, err, ErrInvalidParam)
+		assert.Equals(t, w.Len(), 0)
+	})
+
+	t.Run("IsPreservedByClone", func(t *testing.T) {
+		opts := NewOptions().Use(Strict(), Param("unknown", "x"))
+
+		assert.ErrorIs(t, opts.Clone().Err(), ErrInvalidParam)
+	})
+}
@@ -0,0 +1,387 @@
+// Copyright 2025 The Nanoninja Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+package render
+
+import (
+	"context"
+	"encoding"
+	"fmt"
+	"io"
+	"reflect"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// KeyValue is a key and value pair of an ordered record, written in the
+// order of the slice holding it.
+type KeyValue struct {
+	Key   string
+	Value any
+}
+
+// KeyValues builds an ordered record from alternating keys and values.
+// Keys that are not strings are formatted with fmt.Sprint, and a trailing
+// key without value gets a nil value.
+//
+// Example:
+//
+//	render.Logfmt().Render(w, render.KeyValues("level", "info", "msg", "started"))
+//	// level=info msg=started
+func KeyValues(kv ...any) []KeyValue {
+	record := make([]KeyValue, 0, (len(kv)+1)/2)
+	for i := 0; i < len(kv); i += 2 {
+		key, ok := kv[i].(string)
+		if !ok {
+			key = fmt.Sprint(kv[i])
+		}
+		var value any
+		if i+1 < len(kv) {
+			value = kv[i+1]
+		}
+		record = append(record, KeyValue{Key: key, Value: value})
+	}
+	return record
+}
+
+var (
+	keyValuesType     = reflect.TypeOf([]KeyValue(nil))
+	textMarshalerType = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+)
+
+// logfmtRenderer implements the rendering of records as logfmt lines.
+type logfmtRenderer struct{}
+
+// Logfmt creates a new logfmt renderer, writing each record as a line of
+// space separated key=value pairs.
+func Logfmt() Renderer {
+	return &logfmtRenderer{}
+}
+
+// Render writes data as logfmt using a background context.
+// A record is one of:
+// - map with string keys: pairs sorted by key
+// - struct: one pair per exported field, in declaration order, named by
+// the "logfmt" struct tag when set, skipped when the tag is "-" and when
+// empty with the "omitempty" tag option
+// - []KeyValue: pairs in the order of the slice
+// Slices and arrays of records, and channels of records, are written as
+// one line per record. Channels are read until closed.
+func (r *logfmtRenderer) Render(w io.Writer, data any, opts ...func(*Options)) error {
+	return r.RenderContext(context.Background(), w, data, opts...)
+}
+
+// RenderContext writes data as logfmt with context support.
+// Values are written as-is when possible, and quoted with Go escapes when
+// they are empty or contain spaces, quotes, equal signs or control
+// characters. Nested maps, structs and slices are flattened with dotted
+// keys, such as db.host=localhost or tags.0=web. Values implementing
+// encoding.TextMarshaler, error or fmt.Stringer are written as text, and
+// nil values as null. Characters of keys that logfmt cannot hold are
+// replaced by underscores.
+// Records are streamed one line at a time, checking the context between
+// them. The content type is text/plain by default.
+func (r *logfmtRenderer) RenderContext(ctx context.Context, w io.Writer, data any, opts ...func(*Options)) error {
+	if err := CheckContext(ctx); err != nil {
+		return err
+	}
+	options := NewOptions().
+		Use(MimeTextPlain()).
+		Use(ContextDefaults(ctx)).
+		Use(opts...)
+
+	if err := options.Err(); err != nil {
+		return err
+	}
+
+	v := indirectValue(reflect.ValueOf(data))
+	if !v.IsValid() {
+		return ErrInvalidData
+	}
+	out, err := OutputWriter(w, options)
+	if err != nil {
+		return err
+	}
+
+	var b strings.Builder
+	writeRecord := func(record reflect.Value) error {
+		if err := CheckContext(ctx); err != nil {
+			return err
+		}
+		b.Reset()
+		if err := appendRecord(&b, indirectValue(record)); err != nil {
+			return err
+		}
+		b.WriteByte('\n')
+		_, err := io.WriteString(out, b.String())
+		return err
+	}
+
+	switch {
+	case isLogfmtRecord(v):
+		err = writeRecord(v)
+	case v.Kind() == reflect.Slice || v.Kind() == reflect.Array:
+		for i := 0; i < v.Len() && err == nil; i++ {
+			err = writeRecord(v.Index(i))
+		}
+	case v.Kind() == reflect.Chan && v.Type().ChanDir()&reflect.RecvDir != 0:
+		err = receiveRecords(ctx, v, writeRecord)
+	default:
+		err = ErrInvalidData
+	}
+	if err != nil {
+		return err
+	}
+	return out.Close()
+}
+
+// receiveRecords calls write for every record received from ch, until ch
+// is closed or ctx is done.
+func receiveRecords(ctx context.Context, ch reflect.Value, write func(reflect.Value) error) error {
+	cases := []reflect.SelectCase{
+		{Dir: reflect.SelectRecv, Chan: reflect.ValueOf(ctx.Done())},
+		{Dir: reflect.SelectRecv, Chan: ch},
+	}
+	for {
+		chosen, record, ok := reflect.Select(cases)
+		if chosen == 0 {
+			return ctx.Err()
+		}
+		if !ok {
+			return nil
+		}
+		if err := write(record); err != nil {
+			return err
+		}
+	}
+}
+
+// isLogfmtRecord reports whether v is written as a single record.
+func isLogfmtRecord(v reflect.Value) bool {
+	if v.Type() == keyValuesType {
+		return true
+	}
+	switch v.Kind() {
+	case reflect.Map:
+		return v.Type().Key().Kind() == reflect.String
+	case reflect.Struct:
+		return !v.Type().Implements(textMarshalerType)
+	}
+	return false
+}
+
+// appendRecord appends the pairs of a record, separated by spaces.
+func appendRecord(b *strings.Builder, v reflect.Value) error {
+	if !v.IsValid() || !isLogfmtRecord(v) {
+		return ErrInvalidData
+	}
+	return appendPairs(b, make(map[valueRef]bool), "", v)
+}
+
+// appendPairs appends the pairs of v with keys starting with prefix,
+// flattening nested values. Values referring to themselves return an
+// error wrapping ErrInvalidData.
+func appendPairs(b *strings.Builder, visiting map[valueRef]bool, prefix string, v reflect.Value) error {
+	if ref, ok := valueReference(v); ok {
+		if visiting[ref] {
+			return fmt.Errorf("%w: cycle at key %q", ErrInvalidData, prefix)
+		}
+		visiting[ref] = true
+		defer delete(visiting, ref)
+	}
+	v = indirectValue(v)
+	if !v.IsValid() || isScalarValue(v) {
+		appendPair(b, prefix, v)
+		return nil
+	}
+	switch {
+	case v.Type() == keyValuesType:
+		for _, kv := range v.Interface().([]KeyValue) {
+			if err := appendPairs(b, visiting, logfmtJoin(prefix, kv.Key), reflect.ValueOf(kv.Value)); err != nil {
+				return err
+			}
+		}
+	case v.Kind() == reflect.Map:
+		for _, key := range sortedMapKeys(v) {
+			if err := appendPairs(b, visiting, logfmtJoin(prefix, fmt.Sprint(key.Interface())), v.MapIndex(key)); err != nil {
+				return err
+			}
+		}
+	case v.Kind() == reflect.Struct:
+		typ := v.Type()
+		for i := 0; i < typ.NumField(); i++ {
+			field := typ.Field(i)
+			if !field.IsExported() {
+				continue
+			}
+			name, omitEmpty, skip := fieldTag(field, "logfmt")
+			if skip || omitEmpty && v.Field(i).IsZero() {
+				continue
+			}
+			if name == "" {
+				name = field.Name
+			}
+			if err := appendPairs(b, visiting, logfmtJoin(prefix, name), v.Field(i)); err != nil {
+				return err
+			}
+		}
+	case v.Kind() == reflect.Slice || v.Kind() == reflect.Array:
+		if v.Len() == 0 {
+			appendPair(b, prefix, reflect.ValueOf(""))
+		}
+		for i := 0; i < v.Len(); i++ {
+			if err := appendPairs(b, visiting, logfmtJoin(prefix, strconv.Itoa(i)), v.Index(i)); err != nil {
+				return err
+			}
+		}
+	default:
+		appendPair(b, prefix, v)
+	}
+	return nil
+}
+
+// fieldTag parses the struct tag of a field under the given key, such
+// as `logfmt:"name,omitempty"`. It returns the name set by the tag, empty
+// when unset, whether the omitempty option is set, and whether the field
+// is skipped with "-".
+func fieldTag(field reflect.StructField, key string) (name string, omitEmpty, skip bool) {
+	tag := field.Tag.Get(key)
+	if tag == "-" {
+		return "", false, true
+	}
+	name, opts, _ := strings.Cut(tag, ",")
+	return name, opts == "omitempty", false
+}
+
+// logfmtJoin joins a key to the prefix of its parent with a dot.
+func logfmtJoin(prefix, key string) string {
+	if prefix == "" {
+		return key
+	}
+	return prefix + "." + key
+}
+
+// appendPair appends a key=value pair, preceded by a space unless it is
+// the first pair of the line.
+func appendPair(b *strings.Builder, key string, v reflect.Value) {
+	if b.Len() > 0 {
+		b.WriteByte(' ')
+	}
+	b.WriteString(logfmtKey(key))
+	b.WriteByte('=')
+	b.WriteString(logfmtValue(v))
+}
+
+// logfmtKey replaces the characters of key that cannot appear in a
+// logfmt key by underscores.
+func logfmtKey(key string) string {
+	if key == "" {
+		return "_"
+	}
+	return strings.Map(func(r rune) rune {
+		if r <= ' ' || r == '=' || r == '"' || r == utf8.RuneError || !unicode.IsPrint(r) {
+			return '_'
+		}
+		return r
+	}, key)
+}
+
+// logfmtValue formats a scalar value, quoting it when needed.
+func logfmtValue(v reflect.Value) string {
+	if !v.IsValid() {
+		return "null"
+	}
+	s := textValue(v)
+	if logfmtNeedsQuotes(s) {
+		return strconv.Quote(s)
+	}
+	return s
+}
+
+// textValue formats a scalar value as text. Values implementing
+// encoding.TextMarshaler, error or fmt.Stringer use these methods, byte
+// slices are converted to strings, and floats are written without
+// exponent. Invalid values are empty.
+func textValue(v reflect.Value) string {
+	if !v.IsValid() {
+		return ""
+	}
+	switch value := v.Interface().(type) {
+	case encoding.TextMarshaler:
+		text, err := value.MarshalText()
+		if err != nil {
+			return err.Error()
+		}
+		return string(text)
+	case error:
+		return value.Error()
+	case fmt.Stringer:
+		return value.String()
+	case []byte:
+		return string(value)
+	}
+	switch v.Kind() {
+	case reflect.Float32:
+		return strconv.FormatFloat(v.Float(), 'f', -1, 32)
+	case reflect.Float64:
+		return strconv.FormatFloat(v.Float(), 'f', -1, 64)
+	}
+	return fmt.Sprint(v.Interface())
+}
+
+// logfmtNeedsQuotes reports whether s must be quoted to be read back.
+func logfmtNeedsQuotes(s string) bool {
+	if s == "" {
+		return true
+	}
+	for _, r := range s {
+		if r <= ' ' || r == '=' || r == '"' || r == '\\' || r == utf8.RuneError || !unicode.IsPrint(r) {
+			return true
+		}
+	}
+	return false
+}
+
+// indirectValue follows the pointers and interfaces of v, stopping at
+// values formatted as text. It returns an invalid value for nil.
+func indirectValue(v reflect.Value) reflect.Value {
+	for v.IsValid() && (v.Kind() == reflect.Pointer || v.Kind() == reflect.Interface) {
+		if v.IsNil() {
+			return reflect.Value{}
+		}
+		if v.Kind() == reflect.Pointer && implementsText(v) {
+			return v
+		}
+		v = v.Elem()
+	}
+	return v
+}
+
+// implementsText reports whether v is formatted as text.
+func implementsText(v reflect.Value) bool {
+	if !v.CanInterface() {
+		return false
+	}
+	switch v.Interface().(type) {
+	case encoding.TextMarshaler, error, fmt.Stringer, []byte:
+		return true
+	}
+	return false
+}
+
+// isScalarValue reports whether v is written as a single value.
+func isScalarValue(v reflect.Value) bool {
+	if v.Type() == keyValuesType {
+		return false
+	}
+	if implementsText(v) {
+		return true
+	}
+	switch v.Kind() {
+	case reflect.Map, reflect.Struct, reflect.Slice, reflect.Array:
+		return false
+	}
+	return true
+}
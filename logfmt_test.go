@@ -0,0 +1,224 @@
+// Copyright 2025 The Nanoninja Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+package render
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/nanoninja/assert"
+)
+
+var (
+	_ Renderer = (*logfmtRenderer)(nil)
+	_ Renderer = Logfmt()
+)
+
+// logfmtStatus is a struct record with tags.
+type logfmtStatus struct {
+	Level    string        `logfmt:"level"`
+	Message  string        `logfmt:"msg"`
+	Duration time.Duration `logfmt:"took"`
+	Error    error         `logfmt:"err,omitempty"`
+	Token    string        `logfmt:"-"`
+	DB       struct {
+		Host string `logfmt:"host"`
+		Port int    `logfmt:"port"`
+	} `logfmt:"db"`
+	internal string
+}
+
+// cyclicNode is a record that can refer to itself.
+type cyclicNode struct {
+	Name string
+	Next *cyclicNode
+}
+
+// newCyclicData returns records referring to themselves through a pointer
+// and through a map.
+func newCyclicData() map[string]any {
+	node := &cyclicNode{Name: "a"}
+	node.Next = node
+
+	self := map[string]any{"name": "b"}
+	self["self"] = self
+
+	return map[string]any{"Pointer": node, "Map": self}
+}
+
+func TestLogfmtRenderer(t *testing.T) {
+	t.Run("RendersSortedMaps", func(t *testing.T) {
+		var w bytes.Buffer
+
+		err := Logfmt().Render(&w, map[string]any{"msg": "started", "level": "info", "port": 8080})
+
+		assert.Nil(t, err)
+		assert.Equals(t, w.String(), "level=info msg=started port=8080\n")
+	})
+
+	t.Run("RendersStructsWithTags", func(t *testing.T) {
+		var w bytes.Buffer
+		data := logfmtStatus{Level: "warn", Message: "slow query", Duration: 1500 * time.Millisecond, Token: "secret"}
+		data.DB.Host = "localhost"
+		data.DB.Port = 5432
+
+		err := Logfmt().Render(&w, &data)
+
+		assert.Nil(t, err)
+		assert.Equals(t, w.String(), `level=warn msg="slow query" took=1.5s db.host=localhost db.port=5432`+"\n")
+	})
+
+	t.Run("RendersOrderedKeyValues", func(t *testing.T) {
+		var w bytes.Buffer
+
+		err := Logfmt().Render(&w, KeyValues("z", 1, "a", 2.5, "m", true, "last"))
+
+		assert.Nil(t, err)
+		assert.Equals(t, w.String(), "z=1 a=2.5 m=true last=null\n")
+	})
+
+	t.Run("QuotesAndEscapesValues", func(t *testing.T) {
+		var w bytes.Buffer
+		data := []KeyValue{
+			{"empty", ""},
+			{"space", "a b"},
+			{"equals", "a=b"},
+			{"quote", `say "hi"`},
+			{"backslash", `C:\tmp`},
+			{"newline", "a\nb"},
+			{"unicode", "café"},
+			{"bytes", []byte("raw")},
+		}
+
+		err := Logfmt().Render(&w, data)
+
+		assert.Nil(t, err)
+		assert.Equals(t, w.String(), `empty="" space="a b" equals="a=b" quote="say \"hi\"" backslash="C:\\tmp" newline="a\nb" unicode=café bytes=raw`+"\n")
+	})
+
+	t.Run("SanitizesKeys", func(t *testing.T) {
+		var w bytes.Buffer
+
+		err := Logfmt().Render(&w, []KeyValue{{"user name", 1}, {"a=b", 2}, {"", 3}})
+
+		assert.Nil(t, err)
+		assert.Equals(t, w.String(), "user_name=1 a_b=2 _=3\n")
+	})
+
+	t.Run("FlattensNestedValues", func(t *testing.T) {
+		var w bytes.Buffer
+		data := map[string]any{
+			"http": map[string]any{"status": 200, "headers": map[string]string{"accept": "*/*"}},
+			"tags": []string{"web", "eu"},
+			"none": []int{},
+		}
+
+		err := Logfmt().Render(&w, data)
+
+		assert.Nil(t, err)
+		assert.Equals(t, w.String(), `http.headers.accept=*/* http.status=200 none="" tags.0=web tags.1=eu`+"\n")
+	})
+
+	t.Run("FormatsTextValues", func(t *testing.T) {
+		var w bytes.Buffer
+		at := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+
+		err := Logfmt().Render(&w, KeyValues("at", at, "err", errors.New("disk full"), "ptr", &at))
+
+		assert.Nil(t, err)
+		assert.Equals(t, w.String(), `at=2025-03-01T12:00:00Z err="disk full" ptr=2025-03-01T12:00:00Z`+"\n")
+	})
+
+	t.Run("RendersSlicesOfRecords", func(t *testing.T) {
+		var w bytes.Buffer
+		data := []map[string]int{{"n": 1}, {"n": 2}}
+
+		err := Logfmt().Render(&w, data, UseCRLF())
+
+		assert.Nil(t, err)
+		assert.Equals(t, w.String(), "n=1\r\nn=2\r\n")
+	})
+
+	t.Run("StreamsChannelsOfRecords", func(t *testing.T) {
+		var w bytes.Buffer
+		ch := make(chan []KeyValue, 3)
+		ch <- KeyValues("n", 1)
+		ch <- KeyValues("n", 2)
+		close(ch)
+
+		err := Logfmt().Render(&w, ch)
+
+		assert.Nil(t, err)
+		assert.Equals(t, w.String(), "n=1\nn=2\n")
+	})
+
+	t.Run("StopsStreamingWhenContextIsDone", func(t *testing.T) {
+		var w bytes.Buffer
+		ctx, cancel := context.WithCancel(context.Background())
+		ch := make(chan map[string]int)
+		go func() {
+			ch <- map[string]int{"n": 1}
+			cancel()
+		}()
+
+		err := Logfmt().RenderContext(ctx, &w, ch)
+
+		assert.ErrorIs(t, err, context.Canceled)
+	})
+
+	t.Run("RejectsInvalidData", func(t *testing.T) {
+		tests := map[string]any{
+			"Nil":        nil,
+			"Scalar":     42,
+			"IntKeys":    map[int]string{1: "a"},
+			"SliceItems": []int{1, 2},
+		}
+		for name, data := range tests {
+			t.Run(name, func(t *testing.T) {
+				var w bytes.Buffer
+
+				err := Logfmt().Render(&w, data)
+
+				assert.ErrorIs(t, err, ErrInvalidData)
+			})
+		}
+	})
+
+	t.Run("RejectsCycles", func(t *testing.T) {
+		for name, data := range newCyclicData() {
+			t.Run(name, func(t *testing.T) {
+				var w bytes.Buffer
+
+				err := Logfmt().Render(&w, data)
+
+				assert.ErrorIs(t, err, ErrInvalidData)
+				assert.Equals(t, w.Len(), 0)
+			})
+		}
+	})
+
+	t.Run("RendersSharedReferences", func(t *testing.T) {
+		var w bytes.Buffer
+		shared := &cyclicNode{Name: "s"}
+
+		err := Logfmt().Render(&w, map[string]any{"a": shared, "b": shared})
+
+		assert.Nil(t, err)
+		assert.Equals(t, w.String(), "a.Name=s a.Next=null b.Name=s b.Next=null\n")
+	})
+
+	t.Run("RespectsContextCancellation", func(t *testing.T) {
+		var w bytes.Buffer
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		err := Logfmt().RenderContext(ctx, &w, map[string]int{"n": 1})
+
+		assert.ErrorIs(t, err, context.Canceled)
+		assert.Equals(t, w.Len(), 0)
+	})
+}
@@ -0,0 +1,142 @@
+// Copyright 2025 The Nanoninja Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+package render
+
+import (
+	"context"
+	"io"
+	"time"
+)
+
+// Middleware decorates a Renderer with cross-cutting behavior such as
+// logging, header injection or data enrichment. It receives the next
+// renderer in the chain and returns a renderer wrapping it.
+type Middleware func(Renderer) Renderer
+
+// Chain wraps r with the given middlewares.
+// The first middleware is the outermost one: it runs first before rendering
+// and last after rendering.
+//
+// Example:
+//
+//	renderer := render.Chain(render.JSON(),
+//	    render.Before(addRequestID),
+//	    render.After(logRender),
+//	)
+func Chain(r Renderer, mws ...Middleware) Renderer {
+	for i := len(mws) - 1; i >= 0; i-- {
+		r = mws[i](r)
+	}
+	return r
+}
+
+// RendererFunc adapts an ordinary function to the Renderer interface.
+// It is mostly useful for writing middlewares and test doubles.
+type RendererFunc func(ctx context.Context, w io.Writer, data any, opts ...func(*Options)) error
+
+// Render calls f with a background context.
+func (f RendererFunc) Render(w io.Writer, data any, opts ...func(*Options)) error {
+	return f(context.Background(), w, data, opts...)
+}
+
+// RenderContext calls f.
+func (f RendererFunc) RenderContext(ctx context.Context, w io.Writer, data any, opts ...func(*Options)) error {
+	return f(ctx, w, data, opts...)
+}
+
+// BeforeFunc is called before rendering. It may enrich or replace the data
+// by returning a new value, and set options on o. Returning an error aborts
+// the rendering and nothing is written.
+type BeforeFunc func(ctx context.Context, o *Options, data any) (any, error)
+
+// RenderInfo describes a completed render operation.
+type RenderInfo struct {
+	// Written is the number of bytes written to the destination.
+	Written int64
+
+	// Duration is the time spent in the wrapped renderer.
+	Duration time.Duration
+
+	// Err is the error returned by the wrapped renderer, if any.
+	Err error
+}
+
+// AfterFunc is called once rendering has completed, successfully or not.
+// The options are those resolved by the wrapped renderer, including its
+// default content type.
+type AfterFunc func(ctx context.Context, o *Options, info RenderInfo)
+
+// Before returns a middleware that calls fn before rendering.
+// The options passed to fn start empty; every setting fn makes is applied
+// ahead of the call options, so it takes precedence over the renderer
+// defaults while explicit call options can still override it.
+//
+// Example:
+//
+//	render.Before(func(ctx context.Context, o *render.Options, data any) (any, error) {
+//	    o.Header().Set("X-Request-Id", requestID(ctx))
+//	    return data, nil
+//	})
+func Before(fn BeforeFunc) Middleware {
+	return func(next Renderer) Renderer {
+		return RendererFunc(func(ctx context.Context, w io.Writer, data any, opts ...func(*Options)) error {
+			hooked := NewOptions()
+
+			data, err := fn(ctx, hooked, data)
+			if err != nil {
+				return err
+			}
+			merged := make([]func(*Options), 0, len(opts)+1)
+			merged = append(merged, func(o *Options) { o.merge(hooked) })
+			merged = append(merged, opts...)
+
+			return next.RenderContext(ctx, w, data, merged...)
+		})
+	}
+}
+
+// After returns a middleware that calls fn once rendering has completed.
+// It reports the error, the number of bytes written and the duration of
+// the wrapped renderer. The error returned by the wrapped renderer is
+// passed through unchanged.
+//
+// Example:
+//
+//	render.After(func(ctx context.Context, o *render.Options, info render.RenderInfo) {
+//	    log.Printf("%s: %d bytes in %v (err=%v)", o.Name(), info.Written, info.Duration, info.Err)
+//	})
+func After(fn AfterFunc) Middleware {
+	return func(next Renderer) Renderer {
+		return RendererFunc(func(ctx context.Context, w io.Writer, data any, opts ...func(*Options)) error {
+			var resolved *Options
+
+			cw := &countWriter{w: w}
+			start := time.Now()
+
+			err := next.RenderContext(ctx, cw, data, append(opts[:len(opts):len(opts)], CaptureOptions(&resolved))...)
+			if resolved == nil {
+				// The renderer failed before resolving its options
+				resolved = NewOptions()
+			}
+			fn(ctx, resolved, RenderInfo{
+				Written:  cw.n,
+				Duration: time.Since(start),
+				Err:      err,
+			})
+			return err
+		})
+	}
+}
+
+// countWriter counts the bytes written to the underlying writer.
+type countWriter struct {
+	w io.Writer
+	n int64
+}
+
+func (c *countWriter) Write(p []byte) (int, error) {
+	n, err := c.w.Write(p)
+	c.n += int64(n)
+	return n, err
+}
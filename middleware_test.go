@@ -0,0 +1,188 @@
+// Copyright 2025 The Nanoninja Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+package render
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/nanoninja/assert"
+)
+
+var _ Renderer = RendererFunc(nil)
+
+func TestChain(t *testing.T) {
+	t.Run("ReturnsRendererWithoutMiddlewares", func(t *testing.T) {
+		renderer := Text()
+
+		assert.Equals(t, Chain(renderer), renderer)
+	})
+
+	t.Run("AppliesMiddlewaresOutermostFirst", func(t *testing.T) {
+		var w bytes.Buffer
+		var calls []string
+
+		trace := func(name string) Middleware {
+			return func(next Renderer) Renderer {
+				return RendererFunc(func(ctx context.Context, w io.Writer, data any, opts ...func(*Options)) error {
+					calls = append(calls, "before "+name)
+					err := next.RenderContext(ctx, w, data, opts...)
+					calls = append(calls, "after "+name)
+					return err
+				})
+			}
+		}
+
+		err := Chain(Text(), trace("a"), trace("b")).Render(&w, "hello")
+
+		assert.Nil(t, err)
+		assert.Equals(t, w.String(), "hello")
+		assert.Equals(t, strings.Join(calls, ","), "before a,before b,after b,after a")
+	})
+}
+
+func TestRendererFunc(t *testing.T) {
+	var w bytes.Buffer
+
+	renderer := RendererFunc(func(_ context.Context, w io.Writer, data any, _ ...func(*Options)) error {
+		_, err := io.WriteString(w, data.(string))
+		return err
+	})
+
+	err := renderer.Render(&w, "hello")
+
+	assert.Nil(t, err)
+	assert.Equals(t, w.String(), "hello")
+}
+
+func TestBefore(t *testing.T) {
+	t.Run("ReplacesData", func(t *testing.T) {
+		var w bytes.Buffer
+
+		renderer := Chain(Text(), Before(func(_ context.Context, _ *Options, data any) (any, error) {
+			return strings.ToUpper(data.(string)), nil
+		}))
+
+		err := renderer.Render(&w, "hello")
+
+		assert.Nil(t, err)
+		assert.Equals(t, w.String(), "HELLO")
+	})
+
+	t.Run("InjectsOptions", func(t *testing.T) {
+		var w bytes.Buffer
+		var opts *Options
+
+		renderer := Chain(JSON(), Before(func(_ context.Context, o *Options, data any) (any, error) {
+			o.Header().Set("X-Request-Id", "42")
+			Name("hooked")(o)
+			return data, nil
+		}))
+
+		err := renderer.Render(&w, nil, CaptureOptions(&opts))
+
+		assert.Nil(t, err)
+		assert.Equals(t, opts.Header().Get("X-Request-Id"), "42")
+		assert.Equals(t, opts.Name(), "hooked")
+		assert.Equals(t, opts.ContentType(), "application/json; charset=utf-8")
+	})
+
+	t.Run("CallOptionsTakePrecedence", func(t *testing.T) {
+		var w bytes.Buffer
+		var opts *Options
+
+		renderer := Chain(Text(), Before(func(_ context.Context, o *Options, data any) (any, error) {
+			Name("hooked")(o)
+			return data, nil
+		}))
+
+		err := renderer.Render(&w, "hello", Name("explicit"), CaptureOptions(&opts))
+
+		assert.Nil(t, err)
+		assert.Equals(t, opts.Name(), "explicit")
+	})
+
+	t.Run("AbortsOnError", func(t *testing.T) {
+		var w bytes.Buffer
+
+		expectedErr := errors.New("unauthorized")
+		renderer := Chain(Text(), Before(func(context.Context, *Options, any) (any, error) {
+			return nil, expectedErr
+		}))
+
+		err := renderer.Render(&w, "hello")
+
+		assert.ErrorIs(t, err, expectedErr)
+		assert.Equals(t, w.String(), "")
+	})
+}
+
+func TestAfter(t *testing.T) {
+	t.Run("ReportsSuccessfulRender", func(t *testing.T) {
+		var w bytes.Buffer
+		var info RenderInfo
+		var opts *Options
+
+		renderer := Chain(Text(), After(func(_ context.Context, o *Options, i RenderInfo) {
+			opts, info = o, i
+		}))
+
+		err := renderer.Render(&w, "hello", Name("greeting"))
+
+		assert.Nil(t, err)
+		assert.Nil(t, info.Err)
+		assert.Equals(t, info.Written, int64(5))
+		assert.True(t, info.Duration >= 0)
+		assert.Equals(t, opts.Name(), "greeting")
+		assert.Equals(t, opts.ContentType(), "text/plain; charset=utf-8")
+	})
+
+	t.Run("ReportsRenderError", func(t *testing.T) {
+		var info RenderInfo
+
+		renderer := Chain(Text(), After(func(_ context.Context, _ *Options, i RenderInfo) {
+			info = i
+		}))
+
+		err := renderer.Render(&errorWriterTest{}, "hello")
+
+		assert.NotNil(t, err)
+		assert.Equals(t, info.Err, err)
+		assert.Equals(t, info.Written, int64(0))
+	})
+
+	t.Run("ReportsEmptyOptionsWhenNotResolved", func(t *testing.T) {
+		var opts *Options
+
+		renderer := Chain(Text(), After(func(_ context.Context, o *Options, _ RenderInfo) {
+			opts = o
+		}))
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		err := renderer.RenderContext(ctx, io.Discard, "hello")
+
+		assert.ErrorIs(t, err, context.Canceled)
+		assert.NotNil(t, opts)
+	})
+}
+
+func TestOptionsMerge(t *testing.T) {
+	dst := NewOptions().Use(MimeJSON(), Format(Pretty(), Indent("\t")))
+	src := NewOptions().Use(Name("page"), Param("key", "value"), Format(Prefix("// ")))
+
+	dst.merge(src)
+
+	assert.Equals(t, dst.Name(), "page")
+	assert.Equals(t, dst.Params()["key"], "value")
+	assert.Equals(t, dst.Format().Prefix(), "// ")
+	assert.Equals(t, dst.Format().Indent(), "\t")
+	assert.True(t, dst.Format().Pretty())
+	assert.Equals(t, dst.ContentType(), "application/json; charset=utf-8")
+}
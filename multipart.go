@@ -0,0 +1,122 @@
+// Copyright 2025 The Nanoninja Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+package render
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/textproto"
+	"strings"
+)
+
+// MultipartConfig defines configuration options for multipart/form-data
+// rendering.
+type MultipartConfig struct {
+	// Boundary separates the parts of the body. The default is a random
+	// boundary, generated for each rendering.
+	Boundary string
+}
+
+// multipartRenderer implements the rendering of multipart/form-data bodies.
+type multipartRenderer struct {
+	config MultipartConfig
+}
+
+// Multipart creates a new renderer writing data as a multipart/form-data
+// body with a random boundary. This is the recommended constructor for
+// most use cases.
+func Multipart() Renderer {
+	return NewMultipart(MultipartConfig{})
+}
+
+// NewMultipart creates a multipart/form-data renderer with custom
+// configuration, such as a fixed boundary for reproducible bodies.
+func NewMultipart(c MultipartConfig) Renderer {
+	return &multipartRenderer{config: c}
+}
+
+// Render writes data as a multipart body using a background context.
+// Data is read as by Form: maps, url.Values, structs with "form" struct
+// tags and []KeyValue, where each field is written as a part. Values of
+// type FilePart or *FilePart are written as file parts, and slices of
+// them as repeated file parts with the same name.
+func (r *multipartRenderer) Render(w io.Writer, data any, opts ...func(*Options)) error {
+	return r.RenderContext(context.Background(), w, data, opts...)
+}
+
+// RenderContext writes data as a multipart body with context support.
+// File contents are streamed to the writer, checking the context between
+// chunks so that large uploads can be cancelled.
+// The content type is multipart/form-data with the boundary of the body,
+// set before the options of the call are applied so that WriteResponse
+// and the Content-Type header of Options.Header() hold it.
+// The body is written as-is, ignoring the format options. Invalid
+// boundaries return an error wrapping ErrInvalidParam.
+func (r *multipartRenderer) RenderContext(ctx context.Context, w io.Writer, data any, opts ...func(*Options)) error {
+	if err := CheckContext(ctx); err != nil {
+		return err
+	}
+	mw := multipart.NewWriter(w)
+	if r.config.Boundary != "" {
+		if err := mw.SetBoundary(r.config.Boundary); err != nil {
+			return fmt.Errorf("%w: boundary: %v", ErrInvalidParam, err)
+		}
+	}
+	options := NewOptions().
+		Use(Mime(mw.FormDataContentType())).
+		Use(ContextDefaults(ctx)).
+		Use(opts...)
+
+	if err := options.Err(); err != nil {
+		return err
+	}
+
+	fields, err := formFields(data)
+	if err != nil {
+		return err
+	}
+
+	for _, field := range fields {
+		if err := CheckContext(ctx); err != nil {
+			return err
+		}
+		if field.file == nil {
+			if err := mw.WriteField(field.name, field.value); err != nil {
+				return err
+			}
+			continue
+		}
+		part, err := mw.CreatePart(field.file.header(field.name))
+		if err != nil {
+			return err
+		}
+		if field.file.Reader != nil {
+			if err := copyContext(ctx, part, field.file.Reader); err != nil {
+				return err
+			}
+		}
+	}
+	return mw.Close()
+}
+
+// multipartQuoteReplacer escapes the quoted parameters of a Content-Disposition.
+var multipartQuoteReplacer = strings.NewReplacer(`\`, `\\`, `"`, `\"`)
+
+// header returns the MIME header of the file part named name.
+func (f *FilePart) header(name string) textproto.MIMEHeader {
+	disposition := `form-data; name="` + multipartQuoteReplacer.Replace(name) + `"`
+	if f.Filename != "" {
+		disposition += `; filename="` + multipartQuoteReplacer.Replace(f.Filename) + `"`
+	}
+	contentType := f.ContentType
+	if contentType == "" {
+		contentType = "application/octet-stream"
+	}
+	return textproto.MIMEHeader{
+		"Content-Disposition": {disposition},
+		"Content-Type":        {contentType},
+	}
+}
@@ -0,0 +1,147 @@
+// Copyright 2025 The Nanoninja Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+package render
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/nanoninja/assert"
+)
+
+var (
+	_ Renderer = (*multipartRenderer)(nil)
+	_ Renderer = Multipart()
+)
+
+// multipartUpload is a struct form with a file part.
+type multipartUpload struct {
+	Title  string     `form:"title"`
+	Labels []string   `form:"label"`
+	File   *FilePart  `form:"file"`
+	Extras []FilePart `form:"extra,omitempty"`
+}
+
+func TestMultipartRenderer(t *testing.T) {
+	t.Run("RendersFieldsAndFiles", func(t *testing.T) {
+		var (
+			w       bytes.Buffer
+			options *Options
+		)
+		data := multipartUpload{
+			Title:  "report",
+			Labels: []string{"q1", "2025"},
+			File:   &FilePart{Filename: "report.csv", ContentType: "text/csv", Reader: strings.NewReader("a,b\n1,2\n")},
+			Extras: []FilePart{{Filename: `say "hi".txt`}, {Reader: strings.NewReader("raw")}},
+		}
+
+		err := Multipart().Render(&w, data, CaptureOptions(&options))
+		assert.Nil(t, err)
+
+		mediatype, params, err := mime.ParseMediaType(options.ContentType())
+		assert.Nil(t, err)
+		assert.Equals(t, mediatype, "multipart/form-data")
+
+		reader := multipart.NewReader(&w, params["boundary"])
+		form, err := reader.ReadForm(1 << 20)
+		assert.Nil(t, err)
+		assert.Equals(t, form.Value["title"], []string{"report"})
+		assert.Equals(t, form.Value["label"], []string{"q1", "2025"})
+		assert.Equals(t, len(form.File["file"]), 1)
+
+		file := form.File["file"][0]
+		assert.Equals(t, file.Filename, "report.csv")
+		assert.Equals(t, file.Header.Get("Content-Type"), "text/csv")
+
+		f, err := file.Open()
+		assert.Nil(t, err)
+		content, err := io.ReadAll(f)
+		assert.Nil(t, err)
+		assert.Equals(t, string(content), "a,b\n1,2\n")
+
+		assert.Equals(t, len(form.File["extra"]), 1)
+		assert.Equals(t, form.File["extra"][0].Filename, `say "hi".txt`)
+		assert.Equals(t, form.File["extra"][0].Header.Get("Content-Type"), "application/octet-stream")
+		assert.Equals(t, form.Value["extra"], []string{"raw"})
+	})
+
+	t.Run("UsesFixedBoundary", func(t *testing.T) {
+		var w bytes.Buffer
+		r := NewMultipart(MultipartConfig{Boundary: "xyz"})
+
+		err := r.Render(&w, KeyValues("a", "1", "b", FilePart{Filename: "b.txt", Reader: strings.NewReader("B")}))
+
+		assert.Nil(t, err)
+		assert.Equals(t, w.String(), "--xyz\r\n"+
+			"Content-Disposition: form-data; name=\"a\"\r\n\r\n1\r\n"+
+			"--xyz\r\n"+
+			"Content-Disposition: form-data; name=\"b\"; filename=\"b.txt\"\r\n"+
+			"Content-Type: application/octet-stream\r\n\r\nB\r\n"+
+			"--xyz--\r\n")
+	})
+
+	t.Run("WritesBoundaryToResponse", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		r := NewMultipart(MultipartConfig{Boundary: "xyz"})
+
+		err := r.Render(rec, map[string]string{"a": "1"}, WriteResponse(rec))
+
+		assert.Nil(t, err)
+		assert.Equals(t, rec.Header().Get("Content-Type"), "multipart/form-data; boundary=xyz")
+	})
+
+	t.Run("UsesRandomBoundaries", func(t *testing.T) {
+		var first, second *Options
+		data := map[string]string{"a": "1"}
+
+		assert.Nil(t, Multipart().Render(io.Discard, data, CaptureOptions(&first)))
+		assert.Nil(t, Multipart().Render(io.Discard, data, CaptureOptions(&second)))
+		assert.True(t, first.ContentType() != second.ContentType())
+	})
+
+	t.Run("RejectsInvalidBoundary", func(t *testing.T) {
+		var w bytes.Buffer
+		r := NewMultipart(MultipartConfig{Boundary: "bad\nboundary"})
+
+		err := r.Render(&w, map[string]string{"a": "1"})
+
+		assert.ErrorIs(t, err, ErrInvalidParam)
+		assert.Equals(t, w.Len(), 0)
+	})
+
+	t.Run("RejectsInvalidData", func(t *testing.T) {
+		var w bytes.Buffer
+
+		err := Multipart().Render(&w, []string{"a"})
+
+		assert.ErrorIs(t, err, ErrInvalidData)
+		assert.Equals(t, w.Len(), 0)
+	})
+
+	t.Run("ReturnsContextError", func(t *testing.T) {
+		var w bytes.Buffer
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		err := Multipart().RenderContext(ctx, &w, map[string]string{"a": "1"})
+
+		assert.ErrorIs(t, err, context.Canceled)
+		assert.Equals(t, w.Len(), 0)
+	})
+
+	t.Run("StopsStreamingFilesWhenCanceled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		reader := &cancelingReader{Reader: strings.NewReader(strings.Repeat("x", 100000)), cancel: cancel}
+
+		err := Multipart().RenderContext(ctx, io.Discard, map[string]any{"f": FilePart{Reader: reader}})
+
+		assert.ErrorIs(t, err, context.Canceled)
+	})
+}
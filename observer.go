@@ -0,0 +1,267 @@
+// Copyright 2025 The Nanoninja Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+package render
+
+import (
+	"context"
+	"encoding/json"
+	"expvar"
+	"io"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// RenderEvent describes a render operation reported to an Observer.
+// ContentType, Written, Duration and Err are only set when the render has
+// finished.
+type RenderEvent struct {
+	// Kind identifies the observed renderer, as returned by KindOf.
+	Kind string
+
+	// Name is the template name from the options resolved by the renderer,
+	// including context defaults and options bound with Bind.
+	Name string
+
+	// ContentType is the content type resolved by the renderer.
+	ContentType string
+
+	// Written is the number of bytes written to the destination.
+	Written int64
+
+	// Duration is the time spent rendering.
+	Duration time.Duration
+
+	// Err is the error returned by the renderer, if any.
+	Err error
+}
+
+// Observer receives notifications about render operations.
+// Implementations must be safe for concurrent use.
+type Observer interface {
+	// RenderStarted is called once per render, when the renderer first
+	// resolves its options, or before RenderFinished when it does not.
+	RenderStarted(ctx context.Context, e RenderEvent)
+
+	// RenderFinished is called when the render operation has completed,
+	// successfully or not. It is always preceded by RenderStarted.
+	RenderFinished(ctx context.Context, e RenderEvent)
+}
+
+// Observe returns a middleware notifying obs about every render operation.
+// For accurate kinds, Observe should be the innermost middleware of a chain
+// so that it wraps the actual renderer.
+//
+// Example:
+//
+//	metrics := render.NewMetrics()
+//	metrics.Publish("render")
+//
+//	page := render.Chain(tmpl.HTML("page", tmpl.LoadHTML(src)), render.Observe(metrics))
+func Observe(obs Observer) Middleware {
+	return func(next Renderer) Renderer {
+		kind := KindOf(next)
+
+		return RendererFunc(func(ctx context.Context, w io.Writer, data any, opts ...func(*Options)) error {
+			event := RenderEvent{Kind: kind}
+			started := false
+			start := func() {
+				if !started {
+					started = true
+					obs.RenderStarted(ctx, event)
+				}
+			}
+
+			// The options are read as the renderer resolves them rather than
+			// resolved again. They may be applied several times, such as by
+			// Fallback, so the name of the first resolution is reported and
+			// the last resolved content type is kept
+			capture := func(o *Options) {
+				if !started {
+					event.Name = o.Name()
+					start()
+				}
+				event.ContentType = o.ContentType()
+			}
+			cw := &countWriter{w: w}
+			begin := time.Now()
+
+			err := next.RenderContext(ctx, cw, data, append(opts[:len(opts):len(opts)], capture)...)
+			start()
+
+			event.Written = cw.n
+			event.Duration = time.Since(begin)
+			event.Err = err
+
+			obs.RenderFinished(ctx, event)
+			return err
+		})
+	}
+}
+
+// KindOf returns a short identifier for the type of r, such as
+// "render.jsonRenderer" or "tmpl.HTMLTemplate".
+// Renderers can provide their own identifier with a Kind() string method.
+func KindOf(r Renderer) string {
+	if k, ok := r.(interface{ Kind() string }); ok {
+		return k.Kind()
+	}
+	if r == nil {
+		return ""
+	}
+	return strings.TrimLeft(reflect.TypeOf(r).String(), "*")
+}
+
+// DefaultLatencyBuckets are the upper bounds of the latency histogram used
+// by NewMetrics when no buckets are given.
+var DefaultLatencyBuckets = []time.Duration{
+	time.Millisecond,
+	5 * time.Millisecond,
+	10 * time.Millisecond,
+	25 * time.Millisecond,
+	50 * time.Millisecond,
+	100 * time.Millisecond,
+	250 * time.Millisecond,
+	500 * time.Millisecond,
+	time.Second,
+	2500 * time.Millisecond,
+	5 * time.Second,
+	10 * time.Second,
+}
+
+// RenderStats holds the aggregated metrics of a renderer and template name.
+type RenderStats struct {
+	// Count is the number of completed renders.
+	Count int64 `json:"count"`
+
+	// Errors is the number of renders that returned an error.
+	Errors int64 `json:"errors"`
+
+	// InFlight is the number of renders currently in progress.
+	InFlight int64 `json:"in_flight"`
+
+	// Bytes is the total number of bytes written.
+	Bytes int64 `json:"bytes"`
+
+	// Latency is the distribution of render durations.
+	Latency Histogram `json:"latency"`
+}
+
+// Histogram is a cumulative latency histogram.
+type Histogram struct {
+	// Buckets holds the cumulative counts for each upper bound.
+	Buckets []Bucket `json:"buckets"`
+
+	// Sum is the total of all observed durations.
+	Sum time.Duration `json:"sum_ns"`
+}
+
+// Bucket counts the observations lower than or equal to UpperBound.
+type Bucket struct {
+	UpperBound time.Duration `json:"le_ns"`
+	Count      int64         `json:"count"`
+}
+
+// Metrics is an Observer aggregating render counters and latency histograms
+// per renderer kind and template name. It implements expvar.Var so that
+// it can be published on the /debug/vars endpoint.
+type Metrics struct {
+	mu      sync.Mutex
+	buckets []time.Duration
+	stats   map[string]*RenderStats
+}
+
+// NewMetrics creates a Metrics observer using the given histogram bucket
+// upper bounds, or DefaultLatencyBuckets when none are provided.
+func NewMetrics(buckets ...time.Duration) *Metrics {
+	if len(buckets) == 0 {
+		buckets = DefaultLatencyBuckets
+	}
+	bounds := append([]time.Duration(nil), buckets...)
+	sort.Slice(bounds, func(i, j int) bool { return bounds[i] < bounds[j] })
+
+	return &Metrics{
+		buckets: bounds,
+		stats:   make(map[string]*RenderStats),
+	}
+}
+
+// Publish exposes the metrics through expvar under the given name.
+// Like expvar.Publish, it panics if the name is already registered.
+func (m *Metrics) Publish(name string) {
+	expvar.Publish(name, m)
+}
+
+// RenderStarted implements Observer.
+func (m *Metrics) RenderStarted(_ context.Context, e RenderEvent) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.entry(e).InFlight++
+}
+
+// RenderFinished implements Observer.
+func (m *Metrics) RenderFinished(_ context.Context, e RenderEvent) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	s := m.entry(e)
+	s.InFlight--
+	s.Count++
+	s.Bytes += e.Written
+	if e.Err != nil {
+		s.Errors++
+	}
+	s.Latency.Sum += e.Duration
+	for i := range s.Latency.Buckets {
+		if e.Duration <= s.Latency.Buckets[i].UpperBound {
+			s.Latency.Buckets[i].Count++
+		}
+	}
+}
+
+// Snapshot returns a copy of the current metrics keyed by renderer kind,
+// followed by "/" and the template name when one is set.
+func (m *Metrics) Snapshot() map[string]RenderStats {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	snapshot := make(map[string]RenderStats, len(m.stats))
+	for key, s := range m.stats {
+		c := *s
+		c.Latency.Buckets = append([]Bucket(nil), s.Latency.Buckets...)
+		snapshot[key] = c
+	}
+	return snapshot
+}
+
+// String implements expvar.Var by returning the snapshot as JSON.
+func (m *Metrics) String() string {
+	b, err := json.Marshal(m.Snapshot())
+	if err != nil {
+		return "{}"
+	}
+	return string(b)
+}
+
+// entry returns the stats for the event, creating them if needed.
+// It must be called with the lock held.
+func (m *Metrics) entry(e RenderEvent) *RenderStats {
+	key := e.Kind
+	if e.Name != "" {
+		key += "/" + e.Name
+	}
+	s, ok := m.stats[key]
+	if !ok {
+		s = &RenderStats{}
+		s.Latency.Buckets = make([]Bucket, len(m.buckets))
+		for i, bound := range m.buckets {
+			s.Latency.Buckets[i].UpperBound = bound
+		}
+		m.stats[key] = s
+	}
+	return s
+}
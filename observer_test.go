@@ -0,0 +1,213 @@
+// Copyright 2025 The Nanoninja Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+package render
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"expvar"
+	"io"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/nanoninja/assert"
+)
+
+var (
+	_ Observer   = (*Metrics)(nil)
+	_ expvar.Var = (*Metrics)(nil)
+)
+
+// recordObserver records the events it receives.
+type recordObserver struct {
+	mu       sync.Mutex
+	started  []RenderEvent
+	finished []RenderEvent
+}
+
+func (o *recordObserver) RenderStarted(_ context.Context, e RenderEvent) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.started = append(o.started, e)
+}
+
+func (o *recordObserver) RenderFinished(_ context.Context, e RenderEvent) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.finished = append(o.finished, e)
+}
+
+type kindRenderer struct {
+	Renderer
+}
+
+func (kindRenderer) Kind() string {
+	return "custom"
+}
+
+func TestObserve(t *testing.T) {
+	t.Run("NotifiesStartAndFinish", func(t *testing.T) {
+		var w bytes.Buffer
+
+		obs := &recordObserver{}
+		renderer := Chain(JSON(), Observe(obs))
+
+		err := renderer.Render(&w, map[string]int{"a": 1}, Name("data"))
+
+		assert.Nil(t, err)
+		assert.Len(t, obs.started, 1)
+		assert.Len(t, obs.finished, 1)
+
+		started, finished := obs.started[0], obs.finished[0]
+		assert.Equals(t, started.Kind, "render.jsonRenderer")
+		assert.Equals(t, started.Name, "data")
+		assert.Equals(t, started.ContentType, "")
+		assert.Equals(t, started.Written, int64(0))
+
+		assert.Equals(t, finished.Kind, "render.jsonRenderer")
+		assert.Equals(t, finished.Name, "data")
+		assert.Equals(t, finished.ContentType, "application/json; charset=utf-8")
+		assert.Equals(t, finished.Written, int64(w.Len()))
+		assert.Nil(t, finished.Err)
+	})
+
+	t.Run("ReportsErrors", func(t *testing.T) {
+		obs := &recordObserver{}
+		renderer := Chain(Text(), Observe(obs))
+
+		err := renderer.Render(&errorWriterTest{}, "hello")
+
+		assert.NotNil(t, err)
+		assert.Equals(t, obs.finished[0].Err, err)
+	})
+
+	t.Run("NotifiesStartOnceWhenOptionsAreAppliedTwice", func(t *testing.T) {
+		metrics := NewMetrics()
+		renderer := Chain(Fallback(XML(), JSON()), Observe(metrics))
+
+		err := renderer.Render(io.Discard, map[string]int{"a": 1})
+
+		assert.Nil(t, err)
+		for _, stats := range metrics.Snapshot() {
+			assert.Equals(t, stats.InFlight, int64(0))
+			assert.Equals(t, stats.Count, int64(1))
+		}
+	})
+
+	t.Run("ReportsNameOfResolvedOptions", func(t *testing.T) {
+		obs := &recordObserver{}
+		var applied int
+		count := func(*Options) { applied++ }
+		renderer := Chain(Bind(Text(), Name("bound")), Observe(obs))
+
+		err := renderer.Render(io.Discard, "hello", count)
+
+		assert.Nil(t, err)
+		assert.Equals(t, applied, 1)
+		assert.Equals(t, obs.started[0].Name, "bound")
+		assert.Equals(t, obs.finished[0].Name, "bound")
+	})
+
+	t.Run("NotifiesStartWhenOptionsAreNotResolved", func(t *testing.T) {
+		obs := &recordObserver{}
+		renderer := Chain(Text(), Observe(obs))
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		err := renderer.RenderContext(ctx, io.Discard, "hello")
+
+		assert.ErrorIs(t, err, context.Canceled)
+		assert.Len(t, obs.started, 1)
+		assert.Len(t, obs.finished, 1)
+	})
+}
+
+func TestKindOf(t *testing.T) {
+	assert.Equals(t, KindOf(Text()), "render.textRenderer")
+	assert.Equals(t, KindOf(Buffer(Text())), "render.BufferRenderer")
+	assert.Equals(t, KindOf(kindRenderer{Text()}), "custom")
+	assert.Equals(t, KindOf(nil), "")
+}
+
+func TestMetrics(t *testing.T) {
+	t.Run("AggregatesPerKindAndName", func(t *testing.T) {
+		metrics := NewMetrics(10*time.Millisecond, time.Millisecond)
+		renderer := Chain(Text(), Observe(metrics))
+
+		assert.Nil(t, renderer.Render(io.Discard, "hello", Name("greeting")))
+		assert.Nil(t, renderer.Render(io.Discard, "hi", Name("greeting")))
+		assert.NotNil(t, renderer.Render(&errorWriterTest{}, "hello"))
+
+		snapshot := metrics.Snapshot()
+		assert.Len(t, snapshot, 2)
+
+		greeting := snapshot["render.textRenderer/greeting"]
+		assert.Equals(t, greeting.Count, int64(2))
+		assert.Equals(t, greeting.Errors, int64(0))
+		assert.Equals(t, greeting.InFlight, int64(0))
+		assert.Equals(t, greeting.Bytes, int64(7))
+		assert.Len(t, greeting.Latency.Buckets, 2)
+		assert.Equals(t, greeting.Latency.Buckets[0].UpperBound, time.Millisecond)
+		assert.Equals(t, greeting.Latency.Buckets[1].Count, int64(2))
+
+		anonymous := snapshot["render.textRenderer"]
+		assert.Equals(t, anonymous.Count, int64(1))
+		assert.Equals(t, anonymous.Errors, int64(1))
+	})
+
+	t.Run("TracksInFlightRenders", func(t *testing.T) {
+		metrics := NewMetrics()
+
+		metrics.RenderStarted(context.Background(), RenderEvent{Kind: "k"})
+
+		assert.Equals(t, metrics.Snapshot()["k"].InFlight, int64(1))
+		assert.Len(t, metrics.Snapshot()["k"].Latency.Buckets, len(DefaultLatencyBuckets))
+	})
+
+	t.Run("HistogramIsCumulative", func(t *testing.T) {
+		metrics := NewMetrics(time.Millisecond, time.Second)
+
+		metrics.RenderStarted(context.Background(), RenderEvent{Kind: "k"})
+		metrics.RenderFinished(context.Background(), RenderEvent{Kind: "k", Duration: 500 * time.Millisecond})
+
+		s := metrics.Snapshot()["k"]
+		assert.Equals(t, s.Latency.Buckets[0].Count, int64(0))
+		assert.Equals(t, s.Latency.Buckets[1].Count, int64(1))
+		assert.Equals(t, s.Latency.Sum, 500*time.Millisecond)
+	})
+
+	t.Run("PublishesThroughExpvar", func(t *testing.T) {
+		metrics := NewMetrics()
+		metrics.Publish("render_test_metrics")
+
+		assert.Nil(t, Chain(Text(), Observe(metrics)).Render(io.Discard, "hello"))
+
+		v := expvar.Get("render_test_metrics")
+		assert.NotNil(t, v)
+
+		var decoded map[string]RenderStats
+		assert.Nil(t, json.Unmarshal([]byte(v.String()), &decoded))
+		assert.Equals(t, decoded["render.textRenderer"].Count, int64(1))
+	})
+
+	t.Run("IsSafeForConcurrentUse", func(t *testing.T) {
+		metrics := NewMetrics()
+		renderer := Chain(Text(), Observe(metrics))
+
+		var wg sync.WaitGroup
+		for i := 0; i < 10; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				_ = renderer.Render(io.Discard, "hello")
+			}()
+		}
+		wg.Wait()
+
+		assert.Equals(t, metrics.Snapshot()["render.textRenderer"].Count, int64(10))
+	})
+}
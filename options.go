@@ -9,8 +9,10 @@ import (
 	"mime"
 	"net/http"
 	"net/textproto"
+	"sort"
 	"strings"
 	"time"
+	"unicode/utf8"
 )
 
 // HeaderOptions is a type alias for textproto.MIMEHeader, providing standard
@@ -26,6 +28,9 @@ type Options struct {
 	format  FormatOptions     // Formatting configuration
 	header  HeaderOptions     // All headers including content type and charset
 	params  map[string]string // Additional parameters
+	values  map[string]any    // Typed values set with Set
+	strict  bool              // Whether parameters are validated against keys
+	err     error             // First error reported by an option function
 }
 
 // NewOptions creates a new Options instance with default values.
@@ -38,7 +43,7 @@ func NewOptions() *Options {
 // modifications to the clone do not affect the original:
 //   - Simple fields (name, timeout) are copied directly
 //   - Format options are cloned using FormatOptions.Clone()
-//   - Parameters map is recreated with copied key-value pairs
+//   - Parameters and typed values maps are recreated with copied key-value pairs
 //   - Headers are recreated with copied values for each key
 //
 // This method is useful when you need to modify options without affecting
@@ -49,10 +54,16 @@ func (o *Options) Clone() *Options {
 		timeout: o.timeout,
 		format:  o.format.Clone(),
 		params:  make(map[string]string, len(o.params)),
+		values:  make(map[string]any, len(o.values)),
+		strict:  o.strict,
+		err:     o.err,
 	}
 	for k, v := range o.params {
 		clone.params[k] = v
 	}
+	for k, v := range o.values {
+		clone.values[k] = v
+	}
 	clone.header = make(HeaderOptions)
 	for k, v := range o.header {
 		clone.header[k] = append([]string{}, v...)
@@ -60,6 +71,72 @@ func (o *Options) Clone() *Options {
 	return clone
 }
 
+// merge copies the settings explicitly configured in src into o.
+// Zero values in src are ignored so that they do not reset o.
+func (o *Options) merge(src *Options) {
+	if src.name != "" {
+		o.name = src.name
+	}
+	if src.timeout != 0 {
+		o.timeout = src.timeout
+	}
+	if src.format.prefix != "" {
+		o.format.prefix = src.format.prefix
+		o.format.comment = src.format.comment
+	}
+	if src.format.header != "" {
+		o.format.header = src.format.header
+	}
+	if src.format.lineEnding != "" {
+		o.format.lineEnding = src.format.lineEnding
+	}
+	if src.format.indent != "" {
+		o.format.indent = src.format.indent
+	}
+	if src.format.pretty {
+		o.format.pretty = true
+	}
+	if src.format.args != nil {
+		o.format.args = append([]any(nil), src.format.args...)
+	}
+	for k, v := range src.header {
+		o.header[k] = append([]string{}, v...)
+	}
+	for k, v := range src.params {
+		o.params[k] = v
+	}
+	if len(src.values) > 0 && o.values == nil {
+		o.values = make(map[string]any, len(src.values))
+	}
+	for k, v := range src.values {
+		o.values[k] = v
+	}
+	if src.strict {
+		o.strict = true
+	}
+	if src.err != nil {
+		o.fail(src.err)
+	}
+}
+
+// fail records err unless an earlier error was already reported.
+func (o *Options) fail(err error) {
+	if o.err == nil {
+		o.err = err
+	}
+}
+
+// Err returns the first error reported by an option function, such as a
+// header builder receiving an invalid directive. In strict mode, it also
+// reports parameters that do not match their key. Renderers check it once
+// all options are applied and abort rendering when it is not nil.
+func (o *Options) Err() error {
+	if o.err == nil && o.strict {
+		return o.validateParams()
+	}
+	return o.err
+}
+
 // Name returns the configured template name or identifier.
 // An empty string means no specific template name is set.
 func (o *Options) Name() string {
@@ -92,6 +169,7 @@ func (o *Options) Header() HeaderOptions {
 
 // Params returns the map of additional parameters.
 // These parameters can be used to pass custom configuration to renderers.
+// Typed values set with Set are read with Key.Get instead.
 func (o *Options) Params() map[string]string {
 	return o.params
 }
@@ -107,12 +185,17 @@ func (o *Options) Reset() *Options {
 		pretty: false,
 	}
 	o.params = make(map[string]string)
+	o.values = make(map[string]any)
 	o.header = make(HeaderOptions)
+	o.strict = false
+	o.err = nil
 	return o
 }
 
 // String returns a human-readable representation of the current options configuration.
 // This includes template name, timeout, format settings, headers, and parameters.
+// Headers and parameters are sorted by key, and the error reported by an option
+// function, if any, is listed last.
 func (o *Options) String() string {
 	var b strings.Builder
 
@@ -124,16 +207,39 @@ func (o *Options) String() string {
 	b.WriteString(fmt.Sprintf("  Indent: %q\n", o.format.indent))
 
 	b.WriteString("Headers:\n")
-	for key, values := range o.header {
-		b.WriteString(fmt.Sprintf("  %s: %s\n", key, strings.Join(values, ",")))
+	for _, key := range sortedKeys(o.header) {
+		b.WriteString(fmt.Sprintf("  %s: %s\n", key, strings.Join(o.header[key], ",")))
 	}
 	b.WriteString("Parameters:\n")
+	params := make(map[string]string, len(o.params)+len(o.values))
 	for k, v := range o.params {
-		b.WriteString(fmt.Sprintf("  %s: %s\n", k, v))
+		params[k] = v
+	}
+	for k, v := range o.values {
+		params[k] = fmt.Sprintf("%v", v)
+		if r, ok := v.(rune); ok {
+			params[k] = fmt.Sprintf("%q", r)
+		}
+	}
+	for _, k := range sortedKeys(params) {
+		b.WriteString(fmt.Sprintf("  %s: %s\n", k, params[k]))
+	}
+	if err := o.Err(); err != nil {
+		b.WriteString(fmt.Sprintf("Error: %v\n", err))
 	}
 	return b.String()
 }
 
+// sortedKeys returns the keys of m in increasing order.
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
 // Timeout returns the configured rendering timeout duration.
 // A zero duration means no timeout is set.
 func (o *Options) Timeout() time.Duration {
@@ -141,7 +247,7 @@ func (o *Options) Timeout() time.Duration {
 }
 
 // Use applies one or more option functions to the Options instance.
-// It validates the options after all functions are applied.
+// Errors reported by the option functions are available through Err.
 func (o *Options) Use(opts ...func(*Options)) *Options {
 	for _, opt := range opts {
 		opt(o)
@@ -206,12 +312,13 @@ func Header(headers ...func(HeaderOptions)) func(*Options) {
 //	renderer.Render(w, data, Mime("text/html", "utf-8"))
 func Mime(mediatype string, charset ...string) func(*Options) {
 	return Header(func(o HeaderOptions) {
+		contentType := mediatype
 		if len(charset) > 0 {
-			mediatype = mime.FormatMediaType(mediatype, map[string]string{
+			contentType = mime.FormatMediaType(mediatype, map[string]string{
 				"charset": charset[0],
 			})
 		}
-		o.Set("Content-Type", mediatype)
+		o.Set("Content-Type", contentType)
 	})
 }
 
@@ -236,6 +343,8 @@ func Name(name string) func(*Options) {
 
 // Param adds a custom parameter with the given key and value.
 // It's useful for passing additional configuration to renderers.
+// Prefer Set with a typed Key, which Key.Get reads along with parameters
+// set by Param when they can be parsed as the key type.
 func Param(key, value string) func(*Options) {
 	return func(o *Options) {
 		if o.params == nil {
@@ -246,12 +355,17 @@ func Param(key, value string) func(*Options) {
 }
 
 // Separator returns an option function that sets the CSV field separator.
-// The first character of the string is used as separator.
+// The first character of the string is used as separator and is stored
+// under SeparatorKey. An empty string leaves the separator unchanged.
 // Example:
 //
 //	renderer.Render(w, data, Separator(";"))
 func Separator(sep string) func(*Options) {
-	return Param("separator", sep)
+	return func(o *Options) {
+		if r, size := utf8.DecodeRuneInString(sep); size > 0 {
+			Set(SeparatorKey, r)(o)
+		}
+	}
 }
 
 // Timeout sets a timeout duration for the rendering operation.
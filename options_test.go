@@ -41,6 +41,9 @@ func TestOptions(t *testing.T) {
 		clone.params["newKey"] = "newValue"
 		assert.NotEquals(t, len(clone.params), len(original.Params()))
 
+		Set(SeparatorKey, ';')(clone)
+		assert.Equals(t, SeparatorKey.Get(original), ',')
+
 		clone.header.Set("New-Header", "value")
 		assert.NotEquals(t, len(clone.Header()), len(original.Header()))
 	})
@@ -177,6 +180,43 @@ Parameters:
 		assert.Equals(t, opts.String(), expected)
 	})
 
+	t.Run("StringSortsHeadersAndParameters", func(t *testing.T) {
+		opts := NewOptions()
+		opts.header.Set("X-Request-Id", "42")
+		opts.header.Set("Cache-Control", "no-cache")
+		opts.params["zone"] = "eu"
+		opts.params["area"] = "north"
+		opts.fail(ErrInvalidParam)
+
+		expected := `Template Name: ""
+Timeout: 0s
+Format:
+  Pretty: false
+  Indent: ""
+Headers:
+  Cache-Control: no-cache
+  X-Request-Id: 42
+Parameters:
+  area: north
+  zone: eu
+Error: invalid parameter value
+`
+		assert.Equals(t, opts.String(), expected)
+	})
+
+	t.Run("ErrKeepsFirstReportedError", func(t *testing.T) {
+		opts := NewOptions()
+		assert.Nil(t, opts.Err())
+
+		opts.fail(ErrInvalidParam)
+		opts.fail(ErrNegativeTimeout)
+		assert.ErrorIs(t, opts.Err(), ErrInvalidParam)
+		assert.ErrorIs(t, opts.Clone().Err(), ErrInvalidParam)
+
+		opts.Reset()
+		assert.Nil(t, opts.Err())
+	})
+
 	t.Run("DumpWritesFormattedOptionsToWriter", func(t *testing.T) {
 		opts := NewOptions()
 		opts.name = "template.tmpl"
@@ -234,6 +274,16 @@ Parameters:
 		assert.Equals(t, opts.ContentType(), "text/plain; charset=utf-8")
 	})
 
+	t.Run("MimeCanBeReused", func(t *testing.T) {
+		mime := Mime("text/plain", "utf-8")
+		first, second := NewOptions(), NewOptions()
+
+		mime(first)
+		mime(second)
+
+		assert.Equals(t, second.ContentType(), "text/plain; charset=utf-8")
+	})
+
 	t.Run("MimeUTF9SetsContentTypeWithUTF8", func(t *testing.T) {
 		opts := NewOptions()
 
@@ -267,12 +317,12 @@ Parameters:
 		assert.Equals(t, opts.Params()["key"], "value")
 	})
 
-	t.Run("SeparatorSetsSeparatorParameter", func(t *testing.T) {
+	t.Run("SeparatorSetsSeparatorKey", func(t *testing.T) {
 		opts := NewOptions()
 
 		Separator(";")(opts)
 
-		assert.Equals(t, opts.Params()["separator"], ";")
+		assert.Equals(t, SeparatorKey.Get(opts), ';')
 	})
 
 	t.Run("TimeoutSetsRenderTimeout", func(t *testing.T) {
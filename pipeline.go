@@ -0,0 +1,137 @@
+// Copyright 2025 The Nanoninja Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+package render
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+)
+
+// Transformer wraps a writer with a streaming transformation stage.
+// The returned io.WriteCloser receives the rendered content, transforms it
+// incrementally and forwards the result to the wrapped writer. Close must
+// flush any pending output but must not close the wrapped writer.
+//
+// Transformers are typically compressors, minifiers or digest calculators:
+//
+//	func(w io.Writer) io.WriteCloser { return gzip.NewWriter(w) }
+type Transformer func(io.Writer) io.WriteCloser
+
+// PipelineRenderer chains a sequence of stream transformers after a renderer.
+// Unlike BufferRenderer, content flows through the transformers as it is
+// produced, so the whole output never needs to be held in memory.
+type PipelineRenderer struct {
+	renderer     Renderer      // The underlying renderer producing content
+	transformers []Transformer // Transformation stages in processing order
+}
+
+// Pipeline creates a PipelineRenderer that streams the output of r through
+// the given transformers. Content passes through the transformers in the
+// order they are provided, so the last transformer writes to the destination.
+//
+// Example:
+//
+//	// Minify, then compress the rendered HTML
+//	renderer := Pipeline(page, minify, func(w io.Writer) io.WriteCloser {
+//	    return gzip.NewWriter(w)
+//	})
+//	renderer.Render(w, data)
+func Pipeline(r Renderer, transformers ...Transformer) *PipelineRenderer {
+	return &PipelineRenderer{
+		renderer:     r,
+		transformers: transformers,
+	}
+}
+
+// Render implements pipelined rendering using a background context.
+// See RenderContext for details on the rendering process.
+func (r *PipelineRenderer) Render(w io.Writer, data any, opts ...func(*Options)) error {
+	return r.RenderContext(context.Background(), w, data, opts...)
+}
+
+// RenderContext implements pipelined rendering with context support.
+// The rendering process follows these steps:
+// 1. Builds the transformer chain in front of the provided writer
+// 2. Renders content into the head of the chain
+// 3. Closes every stage in order so pending output is flushed downstream
+//
+// The context is checked before each write reaching the chain, which
+// stops long renders as soon as the context is done. Every stage is closed,
+// even when rendering or closing another stage fails, so that resources
+// held by the stages are released. The errors are then returned together.
+func (r *PipelineRenderer) RenderContext(ctx context.Context, w io.Writer, data any, opts ...func(*Options)) error {
+	if err := CheckContext(ctx); err != nil {
+		return err
+	}
+	stages := make([]io.WriteCloser, len(r.transformers))
+
+	// Build the chain from the destination back to the renderer
+	next := w
+	for i := len(r.transformers) - 1; i >= 0; i-- {
+		stages[i] = r.transformers[i](next)
+		next = stages[i]
+	}
+	errs := make([]error, 0, len(stages)+1)
+	if err := r.renderer.RenderContext(ctx, &contextWriter{ctx: ctx, w: next}, data, opts...); err != nil {
+		errs = append(errs, fmt.Errorf("pipeline render: %w", err))
+	}
+	// Close from the head so each stage flushes into the next one
+	for _, stage := range stages {
+		if err := stage.Close(); err != nil {
+			errs = append(errs, fmt.Errorf("pipeline close: %w", err))
+		}
+	}
+	return joinErrors(errs...)
+}
+
+// PostRenderFunc adapts a whole-content transformation, such as
+// BufferConfig.PostRender, into a Transformer. The stage collects all
+// content written to it and applies fn when closed, so it should only be
+// used for transformations that genuinely need the complete output.
+//
+// Example:
+//
+//	renderer := Pipeline(JSON(), PostRenderFunc(func(b []byte) ([]byte, error) {
+//	    return bytes.ToUpper(b), nil
+//	}))
+func PostRenderFunc(fn func([]byte) ([]byte, error)) Transformer {
+	return func(w io.Writer) io.WriteCloser {
+		return &postRenderWriter{w: w, fn: fn}
+	}
+}
+
+// postRenderWriter buffers content until Close applies the transformation.
+type postRenderWriter struct {
+	w   io.Writer
+	fn  func([]byte) ([]byte, error)
+	buf bytes.Buffer
+}
+
+func (p *postRenderWriter) Write(b []byte) (int, error) {
+	return p.buf.Write(b)
+}
+
+func (p *postRenderWriter) Close() error {
+	content, err := p.fn(p.buf.Bytes())
+	if err != nil {
+		return fmt.Errorf("post-processing: %w", err)
+	}
+	_, err = p.w.Write(content)
+	return err
+}
+
+// contextWriter rejects writes once its context is done.
+type contextWriter struct {
+	ctx context.Context
+	w   io.Writer
+}
+
+func (c *contextWriter) Write(b []byte) (int, error) {
+	if err := CheckContext(c.ctx); err != nil {
+		return 0, err
+	}
+	return c.w.Write(b)
+}
@@ -0,0 +1,226 @@
+// Copyright 2025 The Nanoninja Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+package render
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"hash"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/nanoninja/assert"
+)
+
+var (
+	_ Renderer = Pipeline(nil)
+	_ Renderer = (*PipelineRenderer)(nil)
+)
+
+// upperTransformer uppercases content as it streams through.
+func upperTransformer(w io.Writer) io.WriteCloser {
+	return &funcWriteCloser{
+		write: func(p []byte) (int, error) {
+			if _, err := w.Write(bytes.ToUpper(p)); err != nil {
+				return 0, err
+			}
+			return len(p), nil
+		},
+	}
+}
+
+// digestTransformer records a SHA-256 digest of the content passing through.
+func digestTransformer(h hash.Hash) Transformer {
+	return func(w io.Writer) io.WriteCloser {
+		return &funcWriteCloser{
+			write: io.MultiWriter(w, h).Write,
+		}
+	}
+}
+
+type funcWriteCloser struct {
+	write func([]byte) (int, error)
+	close func() error
+}
+
+func (f *funcWriteCloser) Write(p []byte) (int, error) {
+	return f.write(p)
+}
+
+func (f *funcWriteCloser) Close() error {
+	if f.close == nil {
+		return nil
+	}
+	return f.close()
+}
+
+func TestPipelineRenderer(t *testing.T) {
+	t.Run("RendersWithoutTransformers", func(t *testing.T) {
+		var w bytes.Buffer
+
+		err := Pipeline(&mockRenderer{content: "test content"}).Render(&w, nil)
+
+		assert.Nil(t, err)
+		assert.Equals(t, w.String(), "test content")
+	})
+
+	t.Run("AppliesTransformersInOrder", func(t *testing.T) {
+		var w bytes.Buffer
+
+		h := sha256.New()
+		renderer := Pipeline(
+			&mockRenderer{content: "test content"},
+			upperTransformer,
+			func(w io.Writer) io.WriteCloser { return gzip.NewWriter(w) },
+			digestTransformer(h),
+		)
+
+		err := renderer.Render(&w, nil)
+		assert.Nil(t, err)
+
+		sum := sha256.Sum256(w.Bytes())
+		assert.Equals(t, hex.EncodeToString(h.Sum(nil)), hex.EncodeToString(sum[:]))
+
+		zr, err := gzip.NewReader(&w)
+		assert.Nil(t, err)
+
+		content, err := io.ReadAll(zr)
+		assert.Nil(t, err)
+		assert.Equals(t, string(content), "TEST CONTENT")
+	})
+
+	t.Run("ClosesStagesAfterRendering", func(t *testing.T) {
+		var w bytes.Buffer
+		var closed []string
+
+		stage := func(name string) Transformer {
+			return func(w io.Writer) io.WriteCloser {
+				return &funcWriteCloser{
+					write: w.Write,
+					close: func() error {
+						closed = append(closed, name)
+						return nil
+					},
+				}
+			}
+		}
+
+		err := Pipeline(&mockRenderer{content: "x"}, stage("first"), stage("second")).Render(&w, nil)
+
+		assert.Nil(t, err)
+		assert.Equals(t, strings.Join(closed, ","), "first,second")
+	})
+
+	t.Run("SupportsPostRenderAdapter", func(t *testing.T) {
+		var w bytes.Buffer
+
+		renderer := Pipeline(
+			&mockRenderer{content: "test content"},
+			PostRenderFunc(func(b []byte) ([]byte, error) {
+				return append([]byte("<"), append(b, '>')...), nil
+			}),
+			upperTransformer,
+		)
+
+		err := renderer.Render(&w, nil)
+
+		assert.Nil(t, err)
+		assert.Equals(t, w.String(), "<TEST CONTENT>")
+	})
+
+	t.Run("ReturnsPostRenderAdapterError", func(t *testing.T) {
+		var w bytes.Buffer
+
+		expectedErr := errors.New("post-process error")
+		renderer := Pipeline(
+			&mockRenderer{content: "test content"},
+			PostRenderFunc(func([]byte) ([]byte, error) { return nil, expectedErr }),
+		)
+
+		err := renderer.Render(&w, nil)
+
+		assert.ErrorIs(t, err, expectedErr)
+		assert.Equals(t, w.String(), "")
+	})
+
+	t.Run("HandleUnderlyingRendererError", func(t *testing.T) {
+		var w bytes.Buffer
+		var closed bool
+
+		renderer := Pipeline(
+			&mockRenderer{err: errors.New("render error")},
+			func(w io.Writer) io.WriteCloser {
+				return &funcWriteCloser{write: w.Write, close: func() error {
+					closed = true
+					return nil
+				}}
+			},
+		)
+
+		err := renderer.Render(&w, nil)
+
+		assert.NotNil(t, err)
+		assert.StringContains(t, err.Error(), "render error")
+		assert.True(t, closed)
+	})
+
+	t.Run("ClosesEveryStageWhenOneFails", func(t *testing.T) {
+		var w bytes.Buffer
+		closeErr := errors.New("close error")
+		var closed []int
+
+		stage := func(i int, err error) Transformer {
+			return func(w io.Writer) io.WriteCloser {
+				return &funcWriteCloser{write: w.Write, close: func() error {
+					closed = append(closed, i)
+					return err
+				}}
+			}
+		}
+		renderErr := errors.New("render error")
+		renderer := Pipeline(&mockRenderer{err: renderErr}, stage(0, closeErr), stage(1, nil))
+
+		err := renderer.Render(&w, nil)
+
+		assert.ErrorIs(t, err, renderErr)
+		assert.ErrorIs(t, err, closeErr)
+		assert.Equals(t, closed, []int{0, 1})
+	})
+
+	t.Run("HandleWriteError", func(t *testing.T) {
+		err := Pipeline(&mockRenderer{content: "test content"}, upperTransformer).
+			Render(&errorWriterTest{}, nil)
+
+		assert.NotNil(t, err)
+	})
+
+	t.Run("RespectContextCancellation", func(t *testing.T) {
+		var w bytes.Buffer
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		err := Pipeline(&mockRenderer{content: "test content"}).RenderContext(ctx, &w, nil)
+
+		assert.ErrorIs(t, err, context.Canceled)
+		assert.Equals(t, w.String(), "")
+	})
+
+	t.Run("StopsWritingWhenContextIsDone", func(t *testing.T) {
+		var w bytes.Buffer
+
+		ctx, cancel := context.WithCancel(context.Background())
+		renderer := &mockRenderer{content: "test content", beforeWrite: cancel}
+
+		err := Pipeline(renderer).RenderContext(ctx, &w, nil)
+
+		assert.ErrorIs(t, err, context.Canceled)
+		assert.Equals(t, w.String(), "")
+	})
+}
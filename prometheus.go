@@ -0,0 +1,475 @@
+// Copyright 2025 The Nanoninja Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+package render
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"math"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// MetricType defines the type of a metric family.
+type MetricType string
+
+// Metric types supported by the Prometheus renderer.
+const (
+	// CounterMetric is a cumulative value that only increases.
+	CounterMetric MetricType = "counter"
+
+	// GaugeMetric is a value that can go up and down.
+	GaugeMetric MetricType = "gauge"
+
+	// HistogramMetric samples observations in cumulative buckets.
+	HistogramMetric MetricType = "histogram"
+
+	// SummaryMetric samples observations as quantiles.
+	SummaryMetric MetricType = "summary"
+
+	// UntypedMetric is a value of unknown type. It is the type of
+	// families without Type, exposed as "unknown" by OpenMetrics.
+	UntypedMetric MetricType = "untyped"
+)
+
+// MetricFamily is a group of metrics sharing a name, type and help text,
+// and differing by their labels.
+type MetricFamily struct {
+	// Name is the name of the family, such as "http_requests_total".
+	// OpenMetrics exposes counters under the name without its "_total"
+	// suffix, adding the suffix to their samples.
+	Name string
+
+	// Help is the description written in the HELP line, if any.
+	Help string
+
+	// Type is the metric type. An empty type is UntypedMetric.
+	Type MetricType
+
+	// Unit is the unit written in the UNIT line of OpenMetrics, such as
+	// "seconds". The name must then end with "_" followed by the unit.
+	// It is ignored by the Prometheus format.
+	Unit string
+
+	// Metrics are the samples of the family.
+	Metrics []Metric
+}
+
+// Metric is a sample of a metric family.
+type Metric struct {
+	// Labels are the label names and values identifying the metric
+	// within its family.
+	Labels map[string]string
+
+	// Value is the value of counters, gauges and untyped metrics.
+	Value float64
+
+	// Buckets are the cumulative buckets of histograms. The +Inf bucket
+	// is added from Count when missing.
+	Buckets []MetricBucket
+
+	// Quantiles are the quantiles of summaries.
+	Quantiles []MetricQuantile
+
+	// Count is the number of observations of histograms and summaries.
+	Count uint64
+
+	// Sum is the sum of observations of histograms and summaries.
+	Sum float64
+
+	// Timestamp is the time of the sample, written when not zero.
+	Timestamp time.Time
+}
+
+// MetricBucket counts the observations lower than or equal to UpperBound.
+type MetricBucket struct {
+	UpperBound float64
+	Count      uint64
+}
+
+// MetricQuantile is the value of a quantile, between 0 and 1, of a summary.
+type MetricQuantile struct {
+	Quantile float64
+	Value    float64
+}
+
+var (
+	// metricNamePattern matches valid metric names.
+	metricNamePattern = regexp.MustCompile(`^[a-zA-Z_:][a-zA-Z0-9_:]*$`)
+
+	// labelNamePattern matches valid label names.
+	labelNamePattern = regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_]*$`)
+)
+
+// PrometheusConfig defines configuration options for metrics rendering.
+type PrometheusConfig struct {
+	// OpenMetrics writes the OpenMetrics 1.0.0 text format in place of
+	// the Prometheus text format 0.0.4.
+	OpenMetrics bool
+}
+
+// prometheusRenderer implements the text exposition formats of metrics.
+type prometheusRenderer struct {
+	config PrometheusConfig
+}
+
+// Prometheus creates a new renderer writing metric families in the
+// Prometheus text exposition format, version 0.0.4.
+func Prometheus() Renderer {
+	return NewPrometheus(PrometheusConfig{})
+}
+
+// OpenMetrics creates a new renderer writing metric families in the
+// OpenMetrics text format, version 1.0.0.
+func OpenMetrics() Renderer {
+	return NewPrometheus(PrometheusConfig{OpenMetrics: true})
+}
+
+// NewPrometheus creates a metrics renderer with custom configuration.
+func NewPrometheus(c PrometheusConfig) Renderer {
+	return &prometheusRenderer{config: c}
+}
+
+// Render writes metric families using a background context.
+// Data must be a MetricFamily, a *MetricFamily or a []MetricFamily.
+func (r *prometheusRenderer) Render(w io.Writer, data any, opts ...func(*Options)) error {
+	return r.RenderContext(context.Background(), w, data, opts...)
+}
+
+// RenderContext writes metric families with context support.
+// Families are sorted by name, metrics by labels, and labels by name, so
+// that the output is deterministic. HELP texts and label values are
+// escaped, and special values are written as +Inf, -Inf and NaN.
+// Families are validated before anything is written: invalid metric or
+// label names, reserved labels, duplicated families or label sets,
+// negative counters and decreasing buckets return an error wrapping
+// ErrInvalidMetric.
+// The content type is "text/plain; version=0.0.4", or
+// "application/openmetrics-text; version=1.0.0" for OpenMetrics.
+func (r *prometheusRenderer) RenderContext(ctx context.Context, w io.Writer, data any, opts ...func(*Options)) error {
+	if err := CheckContext(ctx); err != nil {
+		return err
+	}
+	mimeType := MimePrometheus()
+	if r.config.OpenMetrics {
+		mimeType = MimeOpenMetrics()
+	}
+	options := NewOptions().
+		Use(mimeType).
+		Use(ContextDefaults(ctx)).
+		Use(opts...)
+
+	if err := options.Err(); err != nil {
+		return err
+	}
+
+	var families []MetricFamily
+	switch v := data.(type) {
+	case MetricFamily:
+		families = []MetricFamily{v}
+	case *MetricFamily:
+		if v == nil {
+			return ErrInvalidData
+		}
+		families = []MetricFamily{*v}
+	case []MetricFamily:
+		families = v
+	default:
+		return ErrInvalidData
+	}
+
+	e := metricsEncoder{openMetrics: r.config.OpenMetrics}
+	if err := e.encode(families); err != nil {
+		return err
+	}
+	out, err := OutputWriter(w, options)
+	if err != nil {
+		return err
+	}
+	// Line feeds of the data are escaped, so each one ends a line written
+	// by the encoder, while carriage returns are written as data
+	content := e.b.String()
+	for content != "" {
+		line, rest, _ := strings.Cut(content, "\n")
+		if _, err := writeData(out, []byte(line)); err != nil {
+			return err
+		}
+		if _, err := io.WriteString(out, "\n"); err != nil {
+			return err
+		}
+		content = rest
+	}
+	return out.Close()
+}
+
+// metricsEncoder writes metric families in a text exposition format.
+type metricsEncoder struct {
+	openMetrics bool
+	b           strings.Builder
+}
+
+// exposedFamily is a validated family with sorted metrics.
+type exposedFamily struct {
+	MetricFamily
+	name    string          // Name of the family in HELP and TYPE lines
+	sample  string          // Name of the samples of counters, gauges and untyped metrics
+	metrics []exposedMetric // Metrics sorted by labels
+}
+
+// exposedMetric holds a metric with its labels sorted by name.
+type exposedMetric struct {
+	Metric
+	labels [][2]string
+}
+
+// encode validates and writes families.
+func (e *metricsEncoder) encode(families []MetricFamily) error {
+	exposed := make([]exposedFamily, 0, len(families))
+	seen := make(map[string]bool, len(families))
+	for _, family := range families {
+		f, err := e.prepare(family)
+		if err != nil {
+			return err
+		}
+		if seen[f.name] {
+			return fmt.Errorf("%w: duplicate family %q", ErrInvalidMetric, f.name)
+		}
+		seen[f.name] = true
+		exposed = append(exposed, f)
+	}
+	sort.Slice(exposed, func(i, j int) bool { return exposed[i].name < exposed[j].name })
+
+	for _, f := range exposed {
+		e.writeFamily(f)
+	}
+	if e.openMetrics {
+		e.b.WriteString("# EOF\n")
+	}
+	return nil
+}
+
+// prepare validates a family and sorts its metrics.
+func (e *metricsEncoder) prepare(family MetricFamily) (exposedFamily, error) {
+	f := exposedFamily{MetricFamily: family, name: family.Name, sample: family.Name}
+	if f.Type == "" {
+		f.Type = UntypedMetric
+	}
+	if !metricNamePattern.MatchString(f.Name) {
+		return f, fmt.Errorf("%w: invalid name %q", ErrInvalidMetric, f.Name)
+	}
+	var reserved string
+	switch f.Type {
+	case CounterMetric:
+		if e.openMetrics {
+			f.name = strings.TrimSuffix(f.Name, "_total")
+			f.sample = f.name + "_total"
+		}
+	case GaugeMetric, UntypedMetric:
+	case HistogramMetric:
+		reserved = "le"
+	case SummaryMetric:
+		reserved = "quantile"
+	default:
+		return f, fmt.Errorf("%w: %s has unknown type %q", ErrInvalidMetric, f.Name, f.Type)
+	}
+	if e.openMetrics && f.Unit != "" && !strings.HasSuffix(f.name, "_"+f.Unit) {
+		return f, fmt.Errorf("%w: %s does not end with unit %q", ErrInvalidMetric, f.name, f.Unit)
+	}
+
+	seen := make(map[string]bool, len(f.Metrics))
+	for _, m := range f.Metrics {
+		rows := exposedMetric{Metric: m}
+		for _, name := range sortedKeys(m.Labels) {
+			if !labelNamePattern.MatchString(name) || strings.HasPrefix(name, "__") || name == reserved {
+				return f, fmt.Errorf("%w: %s has invalid label %q", ErrInvalidMetric, f.Name, name)
+			}
+			rows.labels = append(rows.labels, [2]string{name, m.Labels[name]})
+		}
+		key := labelsKey(rows.labels)
+		if seen[key] {
+			return f, fmt.Errorf("%w: %s has duplicate labels %v", ErrInvalidMetric, f.Name, m.Labels)
+		}
+		seen[key] = true
+		if err := validateMetric(f.Type, &rows); err != nil {
+			return f, fmt.Errorf("%w: %s: %s", ErrInvalidMetric, f.Name, err)
+		}
+		f.metrics = append(f.metrics, rows)
+	}
+	sort.Slice(f.metrics, func(i, j int) bool {
+		return lessLabels(f.metrics[i].labels, f.metrics[j].labels)
+	})
+	return f, nil
+}
+
+// labelsKey identifies a set of labels sorted by name, written as in the
+// exposition format so that distinct sets never share a key.
+func labelsKey(labels [][2]string) string {
+	var b strings.Builder
+	for _, label := range labels {
+		b.WriteString(label[0] + `="` + escapeLabelValue(label[1]) + `",`)
+	}
+	return b.String()
+}
+
+// validateMetric checks the values of a metric of the given type, sorting
+// its buckets and quantiles.
+func validateMetric(typ MetricType, m *exposedMetric) error {
+	switch typ {
+	case CounterMetric:
+		if m.Value < 0 {
+			return fmt.Errorf("negative counter value %v", m.Value)
+		}
+	case HistogramMetric:
+		buckets := append([]MetricBucket(nil), m.Buckets...)
+		sort.Slice(buckets, func(i, j int) bool { return buckets[i].UpperBound < buckets[j].UpperBound })
+		if len(buckets) == 0 || !math.IsInf(buckets[len(buckets)-1].UpperBound, 1) {
+			buckets = append(buckets, MetricBucket{UpperBound: math.Inf(1), Count: m.Count})
+		}
+		for i, bucket := range buckets {
+			if math.IsNaN(bucket.UpperBound) {
+				return fmt.Errorf("bucket upper bound is NaN")
+			}
+			if i > 0 && bucket.UpperBound == buckets[i-1].UpperBound {
+				return fmt.Errorf("duplicate bucket %v", bucket.UpperBound)
+			}
+			if i > 0 && bucket.Count < buckets[i-1].Count {
+				return fmt.Errorf("bucket %v has fewer observations than the previous one", bucket.UpperBound)
+			}
+		}
+		if buckets[len(buckets)-1].Count != m.Count {
+			return fmt.Errorf("+Inf bucket count %d differs from count %d", buckets[len(buckets)-1].Count, m.Count)
+		}
+		m.Buckets = buckets
+	case SummaryMetric:
+		quantiles := append([]MetricQuantile(nil), m.Quantiles...)
+		sort.Slice(quantiles, func(i, j int) bool { return quantiles[i].Quantile < quantiles[j].Quantile })
+		for _, q := range quantiles {
+			if !(q.Quantile >= 0 && q.Quantile <= 1) {
+				return fmt.Errorf("quantile %v is not between 0 and 1", q.Quantile)
+			}
+		}
+		m.Quantiles = quantiles
+	}
+	return nil
+}
+
+// lessLabels compares two sorted label sets.
+func lessLabels(a, b [][2]string) bool {
+	for i := 0; i < len(a) && i < len(b); i++ {
+		if a[i] != b[i] {
+			if a[i][0] != b[i][0] {
+				return a[i][0] < b[i][0]
+			}
+			return a[i][1] < b[i][1]
+		}
+	}
+	return len(a) < len(b)
+}
+
+// writeFamily writes the HELP, TYPE and UNIT lines of a family, followed
+// by its samples.
+func (e *metricsEncoder) writeFamily(f exposedFamily) {
+	if f.Help != "" {
+		e.b.WriteString("# HELP " + f.name + " " + e.escapeHelp(f.Help) + "\n")
+	}
+	typ := string(f.Type)
+	if e.openMetrics && f.Type == UntypedMetric {
+		typ = "unknown"
+	}
+	e.b.WriteString("# TYPE " + f.name + " " + typ + "\n")
+	if e.openMetrics && f.Unit != "" {
+		e.b.WriteString("# UNIT " + f.name + " " + f.Unit + "\n")
+	}
+	for _, m := range f.metrics {
+		switch f.Type {
+		case HistogramMetric:
+			for _, bucket := range m.Buckets {
+				e.writeSample(f.name+"_bucket", m, "le", formatMetricFloat(bucket.UpperBound), strconv.FormatUint(bucket.Count, 10))
+			}
+			e.writeSample(f.name+"_sum", m, "", "", formatMetricFloat(m.Sum))
+			e.writeSample(f.name+"_count", m, "", "", strconv.FormatUint(m.Count, 10))
+		case SummaryMetric:
+			for _, q := range m.Quantiles {
+				e.writeSample(f.name, m, "quantile", formatMetricFloat(q.Quantile), formatMetricFloat(q.Value))
+			}
+			e.writeSample(f.name+"_sum", m, "", "", formatMetricFloat(m.Sum))
+			e.writeSample(f.name+"_count", m, "", "", strconv.FormatUint(m.Count, 10))
+		default:
+			e.writeSample(f.sample, m, "", "", formatMetricFloat(m.Value))
+		}
+	}
+}
+
+// writeSample writes a sample line, with an optional extra label written
+// after the labels of the metric.
+func (e *metricsEncoder) writeSample(name string, m exposedMetric, extraName, extraValue, value string) {
+	e.b.WriteString(name)
+	if len(m.labels) > 0 || extraName != "" {
+		e.b.WriteByte('{')
+		for i, label := range m.labels {
+			if i > 0 {
+				e.b.WriteByte(',')
+			}
+			e.b.WriteString(label[0] + `="` + escapeLabelValue(label[1]) + `"`)
+		}
+		if extraName != "" {
+			if len(m.labels) > 0 {
+				e.b.WriteByte(',')
+			}
+			e.b.WriteString(extraName + `="` + extraValue + `"`)
+		}
+		e.b.WriteByte('}')
+	}
+	e.b.WriteString(" " + value)
+	if !m.Timestamp.IsZero() {
+		e.b.WriteString(" " + e.formatTimestamp(m.Timestamp))
+	}
+	e.b.WriteByte('\n')
+}
+
+// escapeHelp escapes a HELP text. OpenMetrics also escapes double quotes.
+func (e *metricsEncoder) escapeHelp(s string) string {
+	if e.openMetrics {
+		return escapeLabelValue(s)
+	}
+	return strings.NewReplacer(`\`, `\\`, "\n", `\n`).Replace(s)
+}
+
+// formatTimestamp formats a sample timestamp, in milliseconds for the
+// Prometheus format and in seconds for OpenMetrics.
+func (e *metricsEncoder) formatTimestamp(t time.Time) string {
+	ms := t.UnixNano() / int64(time.Millisecond)
+	if !e.openMetrics {
+		return strconv.FormatInt(ms, 10)
+	}
+	if ms%1000 == 0 {
+		return strconv.FormatInt(ms/1000, 10)
+	}
+	return strconv.FormatFloat(float64(ms)/1000, 'f', 3, 64)
+}
+
+// labelValueReplacer escapes backslashes, double quotes and line feeds.
+var labelValueReplacer = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+// escapeLabelValue escapes a label value.
+func escapeLabelValue(s string) string {
+	return labelValueReplacer.Replace(s)
+}
+
+// formatMetricFloat formats a sample value.
+func formatMetricFloat(v float64) string {
+	switch {
+	case math.IsInf(v, 1):
+		return "+Inf"
+	case math.IsInf(v, -1):
+		return "-Inf"
+	case math.IsNaN(v):
+		return "NaN"
+	}
+	return strconv.FormatFloat(v, 'g', -1, 64)
+}
@@ -0,0 +1,264 @@
+// Copyright 2025 The Nanoninja Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+package render
+
+import (
+	"bytes"
+	"context"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/nanoninja/assert"
+)
+
+var (
+	_ Renderer = (*prometheusRenderer)(nil)
+	_ Renderer = Prometheus()
+	_ Renderer = OpenMetrics()
+)
+
+// prometheusFamilies returns families covering every metric type.
+func prometheusFamilies() []MetricFamily {
+	return []MetricFamily{
+		{
+			Name: "http_requests_total",
+			Help: "Total requests.",
+			Type: CounterMetric,
+			Metrics: []Metric{
+				{Labels: map[string]string{"method": "post", "code": "200"}, Value: 3},
+				{Labels: map[string]string{"method": "get", "code": "200"}, Value: 1027},
+			},
+		},
+		{
+			Name: "request_duration_seconds",
+			Help: "Request latency.",
+			Type: HistogramMetric,
+			Unit: "seconds",
+			Metrics: []Metric{{
+				Buckets: []MetricBucket{{UpperBound: 0.5, Count: 8}, {UpperBound: 0.1, Count: 5}},
+				Count:   10,
+				Sum:     2.75,
+			}},
+		},
+		{
+			Name:    "temperature",
+			Type:    GaugeMetric,
+			Metrics: []Metric{{Value: -3.5}},
+		},
+		{
+			Name: "rpc_duration_seconds",
+			Type: SummaryMetric,
+			Metrics: []Metric{{
+				Labels:    map[string]string{"service": "auth"},
+				Quantiles: []MetricQuantile{{Quantile: 0.99, Value: 0.3}, {Quantile: 0.5, Value: 0.05}},
+				Count:     100,
+				Sum:       8,
+			}},
+		},
+	}
+}
+
+func TestPrometheusRenderer(t *testing.T) {
+	t.Run("RendersSortedFamilies", func(t *testing.T) {
+		var w bytes.Buffer
+
+		err := Prometheus().Render(&w, prometheusFamilies())
+
+		assert.Nil(t, err)
+		assert.Equals(t, w.String(), ""+
+			"# HELP http_requests_total Total requests.\n"+
+			"# TYPE http_requests_total counter\n"+
+			`http_requests_total{code="200",method="get"} 1027`+"\n"+
+			`http_requests_total{code="200",method="post"} 3`+"\n"+
+			"# HELP request_duration_seconds Request latency.\n"+
+			"# TYPE request_duration_seconds histogram\n"+
+			`request_duration_seconds_bucket{le="0.1"} 5`+"\n"+
+			`request_duration_seconds_bucket{le="0.5"} 8`+"\n"+
+			`request_duration_seconds_bucket{le="+Inf"} 10`+"\n"+
+			"request_duration_seconds_sum 2.75\n"+
+			"request_duration_seconds_count 10\n"+
+			"# TYPE rpc_duration_seconds summary\n"+
+			`rpc_duration_seconds{service="auth",quantile="0.5"} 0.05`+"\n"+
+			`rpc_duration_seconds{service="auth",quantile="0.99"} 0.3`+"\n"+
+			`rpc_duration_seconds_sum{service="auth"} 8`+"\n"+
+			`rpc_duration_seconds_count{service="auth"} 100`+"\n"+
+			"# TYPE temperature gauge\n"+
+			"temperature -3.5\n")
+	})
+
+	t.Run("RendersOpenMetrics", func(t *testing.T) {
+		var w bytes.Buffer
+		families := prometheusFamilies()[:3]
+		families = append(families, MetricFamily{Name: "jobs", Metrics: []Metric{{Value: 1}}})
+
+		err := OpenMetrics().Render(&w, families)
+
+		assert.Nil(t, err)
+		assert.Equals(t, w.String(), ""+
+			"# HELP http_requests Total requests.\n"+
+			"# TYPE http_requests counter\n"+
+			`http_requests_total{code="200",method="get"} 1027`+"\n"+
+			`http_requests_total{code="200",method="post"} 3`+"\n"+
+			"# TYPE jobs unknown\n"+
+			"jobs 1\n"+
+			"# HELP request_duration_seconds Request latency.\n"+
+			"# TYPE request_duration_seconds histogram\n"+
+			"# UNIT request_duration_seconds seconds\n"+
+			`request_duration_seconds_bucket{le="0.1"} 5`+"\n"+
+			`request_duration_seconds_bucket{le="0.5"} 8`+"\n"+
+			`request_duration_seconds_bucket{le="+Inf"} 10`+"\n"+
+			"request_duration_seconds_sum 2.75\n"+
+			"request_duration_seconds_count 10\n"+
+			"# TYPE temperature gauge\n"+
+			"temperature -3.5\n"+
+			"# EOF\n")
+	})
+
+	t.Run("SetsContentTypes", func(t *testing.T) {
+		var prometheus, openMetrics *Options
+		family := MetricFamily{Name: "up", Type: GaugeMetric, Metrics: []Metric{{Value: 1}}}
+
+		assert.Nil(t, Prometheus().Render(&bytes.Buffer{}, family, CaptureOptions(&prometheus)))
+		assert.Nil(t, OpenMetrics().Render(&bytes.Buffer{}, &family, CaptureOptions(&openMetrics)))
+
+		assert.Equals(t, prometheus.ContentType(), "text/plain; version=0.0.4; charset=utf-8")
+		assert.Equals(t, openMetrics.ContentType(), "application/openmetrics-text; version=1.0.0; charset=utf-8")
+	})
+
+	t.Run("EscapesHelpAndLabelValues", func(t *testing.T) {
+		family := MetricFamily{
+			Name:    "files",
+			Help:    `Files in C:\data, "quoted"` + "\nsecond line",
+			Metrics: []Metric{{Labels: map[string]string{"path": `C:\a "b"` + "\n"}, Value: 1}},
+		}
+		var prometheus, openMetrics bytes.Buffer
+
+		assert.Nil(t, Prometheus().Render(&prometheus, family))
+		assert.Nil(t, OpenMetrics().Render(&openMetrics, family))
+
+		assert.Equals(t, prometheus.String(), ""+
+			`# HELP files Files in C:\\data, "quoted"\nsecond line`+"\n"+
+			"# TYPE files untyped\n"+
+			`files{path="C:\\a \"b\"\n"} 1`+"\n")
+		assert.Equals(t, openMetrics.String(), ""+
+			`# HELP files Files in C:\\data, \"quoted\"\nsecond line`+"\n"+
+			"# TYPE files unknown\n"+
+			`files{path="C:\\a \"b\"\n"} 1`+"\n"+
+			"# EOF\n")
+	})
+
+	t.Run("AppliesFormatOptionsToLinesOnly", func(t *testing.T) {
+		var w bytes.Buffer
+		family := MetricFamily{
+			Name:    "files",
+			Help:    "first\nsecond\r",
+			Metrics: []Metric{{Labels: map[string]string{"path": "a\r\nb"}, Value: 1}},
+		}
+
+		err := Prometheus().Render(&w, family, Format(Prefix("> "), LineEnding("\n")))
+
+		assert.Nil(t, err)
+		assert.Equals(t, w.String(), ""+
+			`> # HELP files first\nsecond`+"\r\n"+
+			"> # TYPE files untyped\n"+
+			`> files{path="a`+"\r"+`\nb"} 1`+"\n")
+	})
+
+	t.Run("FormatsSpecialValuesAndTimestamps", func(t *testing.T) {
+		at := time.UnixMilli(1700000000123)
+		family := MetricFamily{Name: "values", Type: GaugeMetric, Metrics: []Metric{
+			{Labels: map[string]string{"v": "inf"}, Value: math.Inf(1)},
+			{Labels: map[string]string{"v": "nan"}, Value: math.NaN()},
+			{Labels: map[string]string{"v": "neg"}, Value: math.Inf(-1), Timestamp: at},
+			{Labels: map[string]string{"v": "big"}, Value: 1.5e21},
+		}}
+		var prometheus, openMetrics bytes.Buffer
+
+		assert.Nil(t, Prometheus().Render(&prometheus, family))
+		assert.Nil(t, OpenMetrics().Render(&openMetrics, family))
+
+		assert.Equals(t, prometheus.String(), ""+
+			"# TYPE values gauge\n"+
+			`values{v="big"} 1.5e+21`+"\n"+
+			`values{v="inf"} +Inf`+"\n"+
+			`values{v="nan"} NaN`+"\n"+
+			`values{v="neg"} -Inf 1700000000123`+"\n")
+		assert.StringContains(t, openMetrics.String(), `values{v="neg"} -Inf 1700000000.123`+"\n")
+	})
+
+	t.Run("RejectsInvalidFamilies", func(t *testing.T) {
+		gauge := func(name string, labels map[string]string) MetricFamily {
+			return MetricFamily{Name: name, Type: GaugeMetric, Metrics: []Metric{{Labels: labels}}}
+		}
+		tests := map[string][]MetricFamily{
+			"MetricName":      {gauge("1st", nil)},
+			"LabelName":       {gauge("up", map[string]string{"bad-name": "x"})},
+			"ReservedLabel":   {gauge("up", map[string]string{"__name__": "x"})},
+			"DuplicateFamily": {gauge("up", nil), gauge("up", nil)},
+			"DuplicateLabels": {{Name: "up", Metrics: []Metric{{}, {}}}},
+			"UnknownType":     {{Name: "up", Type: "meter"}},
+			"NegativeCounter": {{Name: "c", Type: CounterMetric, Metrics: []Metric{{Value: -1}}}},
+			"LeLabel": {{Name: "h", Type: HistogramMetric, Metrics: []Metric{
+				{Labels: map[string]string{"le": "1"}},
+			}}},
+			"DecreasingBuckets": {{Name: "h", Type: HistogramMetric, Metrics: []Metric{
+				{Buckets: []MetricBucket{{UpperBound: 1, Count: 5}, {UpperBound: 2, Count: 3}}, Count: 5},
+			}}},
+			"InfBucketCount": {{Name: "h", Type: HistogramMetric, Metrics: []Metric{
+				{Buckets: []MetricBucket{{UpperBound: math.Inf(1), Count: 3}}, Count: 5},
+			}}},
+			"QuantileRange": {{Name: "s", Type: SummaryMetric, Metrics: []Metric{
+				{Quantiles: []MetricQuantile{{Quantile: 1.5}}},
+			}}},
+		}
+		for name, families := range tests {
+			t.Run(name, func(t *testing.T) {
+				var w bytes.Buffer
+
+				err := Prometheus().Render(&w, families)
+
+				assert.ErrorIs(t, err, ErrInvalidMetric)
+				assert.Equals(t, w.Len(), 0)
+			})
+		}
+	})
+
+	t.Run("TellsLabelSetsApart", func(t *testing.T) {
+		var w bytes.Buffer
+		family := MetricFamily{Name: "up", Type: GaugeMetric, Metrics: []Metric{
+			{Labels: map[string]string{"a": "x] [b y"}, Value: 1},
+			{Labels: map[string]string{"a": "x", "b": "y"}, Value: 2},
+		}}
+
+		err := Prometheus().Render(&w, family)
+
+		assert.Nil(t, err)
+		assert.StringContains(t, w.String(), `up{a="x] [b y"} 1`)
+		assert.StringContains(t, w.String(), `up{a="x",b="y"} 2`)
+	})
+
+	t.Run("RejectsMismatchedUnitInOpenMetrics", func(t *testing.T) {
+		family := MetricFamily{Name: "latency", Type: GaugeMetric, Unit: "seconds"}
+
+		assert.Nil(t, Prometheus().Render(&bytes.Buffer{}, family))
+		assert.ErrorIs(t, OpenMetrics().Render(&bytes.Buffer{}, family), ErrInvalidMetric)
+	})
+
+	t.Run("RejectsInvalidData", func(t *testing.T) {
+		assert.ErrorIs(t, Prometheus().Render(&bytes.Buffer{}, map[string]float64{"up": 1}), ErrInvalidData)
+		assert.ErrorIs(t, Prometheus().Render(&bytes.Buffer{}, (*MetricFamily)(nil)), ErrInvalidData)
+	})
+
+	t.Run("RespectsContextCancellation", func(t *testing.T) {
+		var w bytes.Buffer
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		err := Prometheus().RenderContext(ctx, &w, prometheusFamilies())
+
+		assert.ErrorIs(t, err, context.Canceled)
+		assert.Equals(t, w.Len(), 0)
+	})
+}
@@ -0,0 +1,160 @@
+// Copyright 2025 The Nanoninja Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+package render
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"unicode/utf16"
+	"unicode/utf8"
+)
+
+// PropertiesConfig defines configuration options for Java properties
+// rendering.
+type PropertiesConfig struct {
+	// Separator is written between keys and values. The default is "=".
+	Separator string
+}
+
+// propertiesRenderer implements the rendering of structs and maps as Java
+// .properties files.
+type propertiesRenderer struct {
+	config PropertiesConfig
+}
+
+// Properties creates a new Java properties renderer writing key=value
+// lines. This is the recommended constructor for most use cases.
+func Properties() Renderer {
+	return NewProperties(PropertiesConfig{})
+}
+
+// NewProperties creates a Java properties renderer with custom
+// configuration. Empty fields use the defaults of Properties.
+func NewProperties(c PropertiesConfig) Renderer {
+	if c.Separator == "" {
+		c.Separator = "="
+	}
+	return &propertiesRenderer{config: c}
+}
+
+// Render writes data as a .properties file using a background context.
+// Data must be a struct or a map. Struct fields are named by the
+// "properties" struct tag when set, skipped when the tag is "-" and when
+// empty with the "omitempty" tag option. Maps are sorted by key. Nested
+// structs and maps are flattened by joining names with dots, such as
+// database.pool.size, and slices of scalars are joined by commas, items
+// holding a comma returning an error wrapping ErrInvalidData.
+func (r *propertiesRenderer) Render(w io.Writer, data any, opts ...func(*Options)) error {
+	return r.RenderContext(context.Background(), w, data, opts...)
+}
+
+// RenderContext writes data as a .properties file with context support.
+// Keys and values are escaped as by java.util.Properties.store: characters
+// outside printable ASCII are written as \uXXXX escapes, and separators,
+// comment markers and backslashes are escaped with a backslash, so that
+// the output is readable as ISO-8859-1 or UTF-8. Empty keys return an
+// error wrapping ErrInvalidKey before anything is written.
+// The header comment set with HeaderComment is written first, with the
+// marker set with Comment, "#" by default. Markers other than "#" and "!"
+// return an error wrapping ErrInvalidParam. The content type is text/x-java-properties.
+func (r *propertiesRenderer) RenderContext(ctx context.Context, w io.Writer, data any, opts ...func(*Options)) error {
+	if err := CheckContext(ctx); err != nil {
+		return err
+	}
+	options := NewOptions().
+		Use(Mime("text/x-java-properties", "iso-8859-1")).
+		Use(ContextDefaults(ctx)).
+		Use(opts...)
+
+	if err := options.Err(); err != nil {
+		return err
+	}
+
+	marker, err := headerMarker(options, "#", "#", "!")
+	if err != nil {
+		return err
+	}
+
+	walker := configWalker{tag: "properties", fieldName: identityName}
+	sections, err := walker.walk(data)
+	if err != nil {
+		return err
+	}
+
+	var b strings.Builder
+	writeHeaderComment(&b, options, marker, escapeUnicode)
+	for _, sec := range sections {
+		for _, entry := range sec.entries {
+			key := strings.Join(append(append([]string(nil), sec.path...), entry.key), ".")
+			if key == "" {
+				return fmt.Errorf("%w: empty property key", ErrInvalidKey)
+			}
+			b.WriteString(escapeProperty(key, true) + r.config.Separator + escapeProperty(entry.value, false) + "\n")
+		}
+	}
+
+	out, err := OutputWriter(w, options)
+	if err != nil {
+		return err
+	}
+	if _, err := io.WriteString(out, b.String()); err != nil {
+		return err
+	}
+	return out.Close()
+}
+
+// escapeProperty escapes a key or value of a properties file. All spaces
+// of keys are escaped, and only the leading ones of values.
+func escapeProperty(s string, key bool) string {
+	var b strings.Builder
+	for i, r := range s {
+		switch r {
+		case ' ':
+			if key || i == 0 {
+				b.WriteByte('\\')
+			}
+			b.WriteByte(' ')
+		case '\\', '=', ':', '#', '!':
+			b.WriteByte('\\')
+			b.WriteRune(r)
+		case '\t':
+			b.WriteString(`\t`)
+		case '\n':
+			b.WriteString(`\n`)
+		case '\r':
+			b.WriteString(`\r`)
+		case '\f':
+			b.WriteString(`\f`)
+		default:
+			writeUnicodeEscaped(&b, r)
+		}
+	}
+	return b.String()
+}
+
+// escapeUnicode escapes the characters of s outside printable ASCII.
+func escapeUnicode(s string) string {
+	var b strings.Builder
+	for _, r := range s {
+		writeUnicodeEscaped(&b, r)
+	}
+	return b.String()
+}
+
+// writeUnicodeEscaped writes r, as a \uXXXX escape when outside printable
+// ASCII. Characters beyond the Basic Multilingual Plane are written as
+// surrogate pairs.
+func writeUnicodeEscaped(b *strings.Builder, r rune) {
+	if r >= 0x20 && r <= 0x7E {
+		b.WriteRune(r)
+		return
+	}
+	if r1, r2 := utf16.EncodeRune(r); r1 != utf8.RuneError {
+		fmt.Fprintf(b, `\u%04X\u%04X`, r1, r2)
+		return
+	}
+	fmt.Fprintf(b, `\u%04X`, r)
+}
@@ -0,0 +1,106 @@
+// Copyright 2025 The Nanoninja Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+package render
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/nanoninja/assert"
+)
+
+var (
+	_ Renderer = (*propertiesRenderer)(nil)
+	_ Renderer = Properties()
+)
+
+func TestPropertiesRenderer(t *testing.T) {
+	t.Run("RendersStructsWithDottedKeys", func(t *testing.T) {
+		var w bytes.Buffer
+		var opts *Options
+
+		err := Properties().Render(&w, newConfigServer(), CaptureOptions(&opts))
+
+		assert.Nil(t, err)
+		assert.Equals(t, w.String(), ""+
+			"name=api\n"+
+			"port=8080\n"+
+			"hosts=a.local,b.local\n"+
+			"database.url=postgres\\://db/app\n"+
+			"database.pool.maxConns=10\n")
+		assert.Equals(t, opts.ContentType(), "text/x-java-properties; charset=iso-8859-1")
+	})
+
+	t.Run("EscapesKeysAndValues", func(t *testing.T) {
+		var w bytes.Buffer
+		data := []map[string]string{{"key with space": " padded value", "a=b": "x:y", "#comment": "!bang", "path": `C:\tmp`}}
+
+		err := Properties().Render(&w, data[0])
+
+		assert.Nil(t, err)
+		assert.Equals(t, w.String(), ""+
+			`\#comment=\!bang`+"\n"+
+			`a\=b=x\:y`+"\n"+
+			`key\ with\ space=\ padded value`+"\n"+
+			`path=C\:\\tmp`+"\n")
+	})
+
+	t.Run("EscapesUnicode", func(t *testing.T) {
+		var w bytes.Buffer
+
+		err := Properties().Render(&w, map[string]string{"café": "日本 😀\n\t"}, Format(HeaderComment("Überblick")))
+
+		assert.Nil(t, err)
+		assert.Equals(t, w.String(), ""+
+			`# \u00DCberblick`+"\n\n"+
+			`caf\u00E9=\u65E5\u672C \uD83D\uDE00\n\t`+"\n")
+	})
+
+	t.Run("UsesSeparatorAndLineEnding", func(t *testing.T) {
+		var w bytes.Buffer
+
+		err := NewProperties(PropertiesConfig{Separator: " = "}).Render(&w, map[string]int{"a": 1, "b": 2}, UseCRLF())
+
+		assert.Nil(t, err)
+		assert.Equals(t, w.String(), "a = 1\r\nb = 2\r\n")
+	})
+
+	t.Run("UsesCommentMarker", func(t *testing.T) {
+		var w bytes.Buffer
+
+		err := Properties().Render(&w, map[string]int{"a": 1}, Format(Comment("!"), HeaderComment("Generated")))
+
+		assert.Nil(t, err)
+		assert.Equals(t, w.String(), "! Generated\n\na=1\n")
+	})
+
+	t.Run("RejectsInvalidCommentMarker", func(t *testing.T) {
+		var w bytes.Buffer
+
+		err := Properties().Render(&w, map[string]int{"a": 1}, Format(Comment("//")))
+
+		assert.ErrorIs(t, err, ErrInvalidParam)
+		assert.Equals(t, w.Len(), 0)
+	})
+
+	t.Run("RejectsEmptyKeys", func(t *testing.T) {
+		var w bytes.Buffer
+
+		err := Properties().Render(&w, map[string]int{"": 1})
+
+		assert.ErrorIs(t, err, ErrInvalidKey)
+		assert.Equals(t, w.Len(), 0)
+	})
+
+	t.Run("RespectsContextCancellation", func(t *testing.T) {
+		var w bytes.Buffer
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		err := Properties().RenderContext(ctx, &w, map[string]int{"a": 1})
+
+		assert.ErrorIs(t, err, context.Canceled)
+	})
+}
@@ -6,6 +6,7 @@ package render
 import (
 	"context"
 	"io"
+	"mime"
 )
 
 // Renderer defines a common interface for all renderers in the system.
@@ -80,6 +81,53 @@ func MimeYAML() func(*Options) {
 	return MimeUTF8("application/yaml")
 }
 
+// MimeForm provides default application/x-www-form-urlencoded content type
+// options, used for HTML form submissions.
+func MimeForm() func(*Options) {
+	return Mime("application/x-www-form-urlencoded")
+}
+
+// MimeCalendar provides default text/calendar content type options with
+// UTF-8 encoding. The iTIP method of the calendar, such as "REQUEST", is
+// added when not empty, as expected by mail clients for invitations.
+func MimeCalendar(method string) func(*Options) {
+	params := map[string]string{"charset": "utf-8"}
+	if method != "" {
+		params["method"] = method
+	}
+	return Header(func(o HeaderOptions) {
+		o.Set("Content-Type", mime.FormatMediaType("text/calendar", params))
+	})
+}
+
+// MimeVCard provides default text/vcard content type options with UTF-8
+// encoding.
+func MimeVCard() func(*Options) {
+	return MimeUTF8("text/vcard")
+}
+
+// MimeGeoJSON provides default application/geo+json content type options
+// with UTF-8 encoding.
+func MimeGeoJSON() func(*Options) {
+	return MimeUTF8("application/geo+json")
+}
+
+// MimePrometheus provides the content type of the Prometheus text exposition
+// format, version 0.0.4, with UTF-8 encoding.
+func MimePrometheus() func(*Options) {
+	return Header(func(o HeaderOptions) {
+		o.Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	})
+}
+
+// MimeOpenMetrics provides the content type of the OpenMetrics text format,
+// version 1.0.0, with UTF-8 encoding.
+func MimeOpenMetrics() func(*Options) {
+	return Header(func(o HeaderOptions) {
+		o.Set("Content-Type", "application/openmetrics-text; version=1.0.0; charset=utf-8")
+	})
+}
+
 // CheckContext verifies if the context is still valid.
 // It returns nil if the context is valid, or the context error if it's done.
 func CheckContext(ctx context.Context) error {
@@ -69,6 +69,31 @@ func TestMimesTypes(t *testing.T) {
 			opt:      MimeYAML(),
 			expected: "application/yaml; charset=utf-8",
 		},
+		{
+			name:     "MimeForm",
+			opt:      MimeForm(),
+			expected: "application/x-www-form-urlencoded",
+		},
+		{
+			name:     "MimeCalendar",
+			opt:      MimeCalendar(""),
+			expected: "text/calendar; charset=utf-8",
+		},
+		{
+			name:     "MimeCalendarWithMethod",
+			opt:      MimeCalendar("REQUEST"),
+			expected: "text/calendar; charset=utf-8; method=REQUEST",
+		},
+		{
+			name:     "MimeVCard",
+			opt:      MimeVCard(),
+			expected: "text/vcard; charset=utf-8",
+		},
+		{
+			name:     "MimeGeoJSON",
+			opt:      MimeGeoJSON(),
+			expected: "application/geo+json; charset=utf-8",
+		},
 	}
 
 	for _, tt := range tests {
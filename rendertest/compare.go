@@ -0,0 +1,220 @@
+// Copyright 2025 The Nanoninja Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+package rendertest
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"mime"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+	"testing"
+)
+
+// Equal compares got and want according to the given content type:
+//   - JSON types, including +json suffixes, use EqualJSON
+//   - XML types, including +xml suffixes, use EqualXML
+//   - HTML uses EqualHTML
+//   - Other types are compared byte for byte
+//
+// It reports whether the contents are equal.
+func Equal(t testing.TB, contentType string, got, want []byte) bool {
+	t.Helper()
+
+	mediatype, _, _ := mime.ParseMediaType(contentType)
+	switch {
+	case mediatype == "application/json" || strings.HasSuffix(mediatype, "+json"):
+		return EqualJSON(t, got, want)
+	case mediatype == "application/xml" || mediatype == "text/xml" || strings.HasSuffix(mediatype, "+xml"):
+		return EqualXML(t, got, want)
+	case mediatype == "text/html":
+		return EqualHTML(t, got, want)
+	}
+	if !bytes.Equal(got, want) {
+		t.Errorf("content mismatch:\n got: %q\nwant: %q", got, want)
+		return false
+	}
+	return true
+}
+
+// EqualJSON reports whether got and want hold the same JSON value,
+// regardless of formatting and object key order. Numbers are compared
+// by their exact value, so that 1.0 equals 1 while large integers that
+// differ beyond float64 precision are told apart.
+func EqualJSON(t testing.TB, got, want []byte) bool {
+	t.Helper()
+	return report(t, "JSON", got, want, jsonEqual)
+}
+
+// EqualXML reports whether got and want hold the same XML document,
+// regardless of formatting and attribute order. Comments, processing
+// instructions and whitespace-only text are ignored.
+func EqualXML(t testing.TB, got, want []byte) bool {
+	t.Helper()
+	return report(t, "XML", got, want, xmlEqual)
+}
+
+// EqualHTML reports whether got and want are the same HTML, ignoring
+// differences in whitespace: runs of whitespace are treated as a single
+// space and whitespace between a closing > and an opening < is ignored.
+// Whitespace next to text is kept, as it affects rendering. The comparison
+// is textual, so whitespace in elements such as pre is not preserved.
+func EqualHTML(t testing.TB, got, want []byte) bool {
+	t.Helper()
+	return report(t, "HTML", got, want, func(a, b []byte) (bool, error) {
+		return normalizeHTML(a) == normalizeHTML(b), nil
+	})
+}
+
+// report runs a comparison and reports its failure to t.
+func report(t testing.TB, format string, got, want []byte, equal func(a, b []byte) (bool, error)) bool {
+	t.Helper()
+
+	ok, err := equal(got, want)
+	if err != nil {
+		t.Errorf("%s comparison: %v", format, err)
+		return false
+	}
+	if !ok {
+		t.Errorf("%s mismatch:\n got: %s\nwant: %s", format, got, want)
+	}
+	return ok
+}
+
+// jsonEqual reports whether a and b decode to the same JSON value.
+func jsonEqual(a, b []byte) (bool, error) {
+	va, err := decodeJSON(a)
+	if err != nil {
+		return false, fmt.Errorf("got: %w", err)
+	}
+	vb, err := decodeJSON(b)
+	if err != nil {
+		return false, fmt.Errorf("want: %w", err)
+	}
+	return reflect.DeepEqual(va, vb), nil
+}
+
+// decodeJSON decodes a single JSON value, keeping numbers as literals.
+func decodeJSON(data []byte) (any, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+
+	var v any
+	if err := dec.Decode(&v); err != nil {
+		return nil, err
+	}
+	if _, err := dec.Token(); err != io.EOF {
+		return nil, errors.New("unexpected data after JSON value")
+	}
+	return normalizeNumbers(v), nil
+}
+
+// exactNumber is the exact value of a JSON number, as a reduced fraction.
+type exactNumber string
+
+// normalizeNumbers rewrites numbers so that equal values with different
+// literals, such as 1.0 and 1, compare equal. Numbers are parsed as exact
+// rationals rather than floats, which would round large integers.
+func normalizeNumbers(v any) any {
+	switch v := v.(type) {
+	case json.Number:
+		if r, ok := new(big.Rat).SetString(v.String()); ok {
+			return exactNumber(r.RatString())
+		}
+		return exactNumber(v.String())
+	case map[string]any:
+		for k, item := range v {
+			v[k] = normalizeNumbers(item)
+		}
+	case []any:
+		for i, item := range v {
+			v[i] = normalizeNumbers(item)
+		}
+	}
+	return v
+}
+
+// xmlNode is a simplified XML element used for semantic comparison.
+type xmlNode struct {
+	Name     xml.Name
+	Attrs    []xml.Attr
+	Text     string
+	Children []*xmlNode
+}
+
+// xmlEqual reports whether a and b parse to the same XML tree.
+func xmlEqual(a, b []byte) (bool, error) {
+	na, err := parseXML(a)
+	if err != nil {
+		return false, fmt.Errorf("got: %w", err)
+	}
+	nb, err := parseXML(b)
+	if err != nil {
+		return false, fmt.Errorf("want: %w", err)
+	}
+	return reflect.DeepEqual(na, nb), nil
+}
+
+// parseXML builds the tree of the root element of data.
+func parseXML(data []byte) (*xmlNode, error) {
+	dec := xml.NewDecoder(bytes.NewReader(data))
+	root := &xmlNode{}
+	stack := []*xmlNode{root}
+
+	for {
+		tok, err := dec.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		parent := stack[len(stack)-1]
+
+		switch tok := tok.(type) {
+		case xml.StartElement:
+			attrs := append([]xml.Attr(nil), tok.Attr...)
+			sort.Slice(attrs, func(i, j int) bool {
+				if attrs[i].Name.Space != attrs[j].Name.Space {
+					return attrs[i].Name.Space < attrs[j].Name.Space
+				}
+				return attrs[i].Name.Local < attrs[j].Name.Local
+			})
+			node := &xmlNode{Name: tok.Name, Attrs: attrs}
+			parent.Children = append(parent.Children, node)
+			stack = append(stack, node)
+		case xml.EndElement:
+			stack = stack[:len(stack)-1]
+		case xml.CharData:
+			parent.Text += strings.TrimSpace(string(tok))
+		}
+	}
+	if len(root.Children) != 1 {
+		return nil, fmt.Errorf("expected a single root element, found %d", len(root.Children))
+	}
+	return root.Children[0], nil
+}
+
+var (
+	// spacePattern matches runs of whitespace.
+	spacePattern = regexp.MustCompile(`\s+`)
+
+	// interTagPattern matches whitespace between two tags.
+	interTagPattern = regexp.MustCompile(`>\s+<`)
+)
+
+// normalizeHTML collapses whitespace so that formatting differences
+// do not affect comparisons.
+func normalizeHTML(data []byte) string {
+	s := spacePattern.ReplaceAllString(string(data), " ")
+	s = interTagPattern.ReplaceAllString(s, "><")
+	return strings.TrimSpace(s)
+}
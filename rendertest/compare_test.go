@@ -0,0 +1,128 @@
+// Copyright 2025 The Nanoninja Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+package rendertest
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/nanoninja/assert"
+)
+
+// fakeTB records the failures reported by the helpers under test.
+type fakeTB struct {
+	testing.TB
+	errors []string
+	fatal  bool
+}
+
+func (f *fakeTB) Helper() {}
+
+func (f *fakeTB) Errorf(format string, args ...any) {
+	f.errors = append(f.errors, fmt.Sprintf(format, args...))
+}
+
+func (f *fakeTB) Fatalf(format string, args ...any) {
+	f.fatal = true
+	f.Errorf(format, args...)
+}
+
+func TestEqualJSON(t *testing.T) {
+	tests := []struct {
+		name     string
+		got      string
+		want     string
+		expected bool
+	}{
+		{name: "IgnoresFormattingAndKeyOrder", got: `{"b":[1,2],"a":{"x":true}}`, want: "{\n  \"a\": {\"x\": true},\n  \"b\": [1, 2]\n}", expected: true},
+		{name: "ComparesNumbersByValue", got: `{"n":1.0}`, want: `{"n":1}`, expected: true},
+		{name: "ComparesExponentsByValue", got: `[1e2,0.5]`, want: `[100,5E-1]`, expected: true},
+		{name: "DetectsLargeIntegers", got: `9007199254740993`, want: `9007199254740992`, expected: false},
+		{name: "DetectsDifferentValues", got: `{"a":1}`, want: `{"a":2}`, expected: false},
+		{name: "DetectsArrayOrder", got: `[1,2]`, want: `[2,1]`, expected: false},
+		{name: "RejectsInvalidJSON", got: `{"a":`, want: `{}`, expected: false},
+		{name: "RejectsTrailingData", got: `{} {}`, want: `{}`, expected: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tb := &fakeTB{}
+
+			assert.Equals(t, EqualJSON(tb, []byte(tt.got), []byte(tt.want)), tt.expected)
+			assert.Equals(t, len(tb.errors) == 0, tt.expected)
+		})
+	}
+}
+
+func TestEqualXML(t *testing.T) {
+	tests := []struct {
+		name     string
+		got      string
+		want     string
+		expected bool
+	}{
+		{
+			name:     "IgnoresFormattingAndAttributeOrder",
+			got:      `<?xml version="1.0"?><user id="1" role="admin"><name>Ana</name></user>`,
+			want:     "<user role=\"admin\" id=\"1\">\n  <!-- comment -->\n  <name> Ana </name>\n</user>",
+			expected: true,
+		},
+		{name: "DetectsDifferentText", got: `<a><b>1</b></a>`, want: `<a><b>2</b></a>`, expected: false},
+		{name: "DetectsChildOrder", got: `<a><b/><c/></a>`, want: `<a><c/><b/></a>`, expected: false},
+		{name: "DetectsNamespaces", got: `<a xmlns="urn:x"/>`, want: `<a/>`, expected: false},
+		{name: "RejectsInvalidXML", got: `<a>`, want: `<a/>`, expected: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tb := &fakeTB{}
+
+			assert.Equals(t, EqualXML(tb, []byte(tt.got), []byte(tt.want)), tt.expected)
+			assert.Equals(t, len(tb.errors) == 0, tt.expected)
+		})
+	}
+}
+
+func TestEqualHTML(t *testing.T) {
+	t.Run("IgnoresWhitespace", func(t *testing.T) {
+		got := "<ul>\n  <li>One  item</li>\n  <li>Two</li>\n</ul>\n"
+		want := "<ul><li>One item</li><li>Two</li></ul>"
+
+		assert.True(t, EqualHTML(&fakeTB{}, []byte(got), []byte(want)))
+	})
+
+	t.Run("DetectsDifferentMarkup", func(t *testing.T) {
+		tb := &fakeTB{}
+
+		assert.False(t, EqualHTML(tb, []byte("<p>One</p>"), []byte("<div>One</div>")))
+		assert.Len(t, tb.errors, 1)
+	})
+
+	t.Run("KeepsWhitespaceNextToText", func(t *testing.T) {
+		tb := &fakeTB{}
+
+		assert.False(t, EqualHTML(tb, []byte("<b>a</b> b"), []byte("<b>a</b>b")))
+		assert.False(t, EqualHTML(tb, []byte("a <b>b</b>"), []byte("a<b>b</b>")))
+		assert.Len(t, tb.errors, 2)
+	})
+}
+
+func TestEqual(t *testing.T) {
+	tests := []struct {
+		contentType string
+		got         string
+		want        string
+		expected    bool
+	}{
+		{contentType: "application/json", got: `{"a": 1}`, want: `{"a":1}`, expected: true},
+		{contentType: "application/problem+json", got: `{"a": 1}`, want: `{"a":1}`, expected: true},
+		{contentType: "application/xml; charset=utf-8", got: "<a>\n</a>", want: `<a/>`, expected: true},
+		{contentType: "text/html; charset=utf-8", got: "<p>\n  <b>x</b>\n</p>", want: `<p><b>x</b></p>`, expected: true},
+		{contentType: "text/plain", got: "a ", want: "a", expected: false},
+		{contentType: "text/plain", got: "a", want: "a", expected: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.contentType, func(t *testing.T) {
+			assert.Equals(t, Equal(&fakeTB{}, tt.contentType, []byte(tt.got), []byte(tt.want)), tt.expected)
+		})
+	}
+}
@@ -0,0 +1,356 @@
+// Copyright 2025 The Nanoninja Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+package rendertest
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"mime"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/nanoninja/render"
+)
+
+// Names of the checks run by Conformance, to be used with Skip.
+const (
+	// CheckContextCanceled verifies that a cancelled context aborts
+	// rendering with the context error before anything is written.
+	CheckContextCanceled = "ContextCanceled"
+
+	// CheckRenderContext verifies that Render and RenderContext with a
+	// background context produce the same output.
+	CheckRenderContext = "RenderMatchesRenderContext"
+
+	// CheckDeterministic verifies that rendering the same data twice
+	// produces the same output.
+	CheckDeterministic = "Deterministic"
+
+	// CheckOptions verifies that options are resolved, with a content type.
+	CheckOptions = "ResolvesOptions"
+
+	// CheckOptionErrors verifies that errors reported by Options.Err
+	// abort rendering before anything is written.
+	CheckOptionErrors = "ReportsOptionErrors"
+
+	// CheckContextDefaults verifies that defaults set with
+	// render.WithDefaults are applied and overridden by call options.
+	CheckContextDefaults = "ContextDefaults"
+
+	// CheckPrefix verifies that the prefix format option starts the
+	// output and is only added at the start of lines, leaving the content
+	// unchanged otherwise. Line breaks of the data, such as in quoted CSV
+	// fields, may be left without prefix. It only applies to textual
+	// content types.
+	CheckPrefix = "Prefix"
+
+	// CheckLineEnding verifies that the line ending format option is used
+	// for the line breaks written by the renderer, leaving the content
+	// unchanged otherwise. It only applies to textual content types.
+	CheckLineEnding = "LineEnding"
+
+	// CheckWriterErrors verifies that errors of the writer are returned.
+	CheckWriterErrors = "WriterErrors"
+)
+
+// ConformanceOption configures the Conformance suite.
+type ConformanceOption func(*conformance)
+
+// conformance holds the configuration of a conformance run.
+type conformance struct {
+	samples []any
+	opts    []func(*render.Options)
+	skip    map[string]bool
+}
+
+// Samples sets the data rendered by each check. The default sample is a
+// multi-line string, which renderers expecting other types must replace.
+func Samples(data ...any) ConformanceOption {
+	return func(c *conformance) { c.samples = data }
+}
+
+// RenderOptions sets options given to every rendering, such as the
+// template name of a template renderer.
+func RenderOptions(opts ...func(*render.Options)) ConformanceOption {
+	return func(c *conformance) { c.opts = append(c.opts, opts...) }
+}
+
+// Skip disables the named checks, for renderers documenting a different
+// behavior, such as binary renderers ignoring format options.
+func Skip(checks ...string) ConformanceOption {
+	return func(c *conformance) {
+		for _, name := range checks {
+			c.skip[name] = true
+		}
+	}
+}
+
+// check is a single conformance check run for one sample.
+type check struct {
+	name string
+	run  func(r render.Renderer, c *conformance, data any) error
+}
+
+// checks lists the conformance checks in the order they are run.
+var checks = []check{
+	{CheckContextCanceled, checkContextCanceled},
+	{CheckRenderContext, checkRenderContext},
+	{CheckDeterministic, checkDeterministic},
+	{CheckOptions, checkOptions},
+	{CheckOptionErrors, checkOptionErrors},
+	{CheckContextDefaults, checkContextDefaults},
+	{CheckPrefix, checkPrefix},
+	{CheckLineEnding, checkLineEnding},
+	{CheckWriterErrors, checkWriterErrors},
+}
+
+// Conformance runs a suite of checks verifying that r behaves like the
+// built-in renderers: context handling, option resolution and precedence,
+// format options and error reporting. Each check runs as a subtest named
+// after its Check constant.
+//
+// Example:
+//
+//	func TestTableRenderer(t *testing.T) {
+//	    rendertest.Conformance(t, NewTable(),
+//	        rendertest.Samples([][]string{{"a", "b"}}),
+//	    )
+//	}
+func Conformance(t *testing.T, r render.Renderer, opts ...ConformanceOption) {
+	t.Helper()
+
+	c := &conformance{
+		samples: []any{"conformance\nsample"},
+		skip:    make(map[string]bool),
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	for _, chk := range checks {
+		chk := chk
+		t.Run(chk.name, func(t *testing.T) {
+			if c.skip[chk.name] {
+				t.Skip("skipped by configuration")
+			}
+			for i, data := range c.samples {
+				if err := chk.run(r, c, data); err != nil {
+					t.Errorf("sample %d (%T): %v", i, data, err)
+				}
+			}
+		})
+	}
+}
+
+// render renders data with the configured options followed by opts.
+func (c *conformance) render(ctx context.Context, r render.Renderer, data any, opts ...func(*render.Options)) ([]byte, *render.Options, error) {
+	var (
+		w        bytes.Buffer
+		resolved *render.Options
+	)
+	all := append([]func(*render.Options){}, c.opts...)
+	all = append(all, opts...)
+	all = append(all, render.CaptureOptions(&resolved))
+
+	err := r.RenderContext(ctx, &w, data, all...)
+	return w.Bytes(), resolved, err
+}
+
+func checkContextCanceled(r render.Renderer, c *conformance, data any) error {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	out, _, err := c.render(ctx, r, data)
+	if !errors.Is(err, context.Canceled) {
+		return fmt.Errorf("RenderContext with a cancelled context returned %v, want context.Canceled", err)
+	}
+	if len(out) > 0 {
+		return fmt.Errorf("RenderContext with a cancelled context wrote %q", out)
+	}
+	return nil
+}
+
+func checkRenderContext(r render.Renderer, c *conformance, data any) error {
+	var w bytes.Buffer
+
+	if err := r.Render(&w, data, c.opts...); err != nil {
+		return fmt.Errorf("Render: %v", err)
+	}
+	out, _, err := c.render(context.Background(), r, data)
+	if err != nil {
+		return fmt.Errorf("RenderContext: %v", err)
+	}
+	if !bytes.Equal(w.Bytes(), out) {
+		return fmt.Errorf("Render wrote %q, RenderContext wrote %q", w.Bytes(), out)
+	}
+	return nil
+}
+
+func checkDeterministic(r render.Renderer, c *conformance, data any) error {
+	first, _, err := c.render(context.Background(), r, data)
+	if err != nil {
+		return err
+	}
+	second, _, err := c.render(context.Background(), r, data)
+	if err != nil {
+		return err
+	}
+	if !bytes.Equal(first, second) {
+		return fmt.Errorf("renderings differ: %q and %q", first, second)
+	}
+	return nil
+}
+
+func checkOptions(r render.Renderer, c *conformance, data any) error {
+	_, resolved, err := c.render(context.Background(), r, data)
+	if err != nil {
+		return err
+	}
+	if resolved == nil {
+		return errors.New("options were not resolved: call options are not applied")
+	}
+	if resolved.ContentType() == "" {
+		return errors.New("no content type set")
+	}
+	return nil
+}
+
+func checkOptionErrors(r render.Renderer, c *conformance, data any) error {
+	out, _, err := c.render(context.Background(), r, data, render.CacheControl(render.MaxAge(-time.Second)))
+	if !errors.Is(err, render.ErrInvalidParam) {
+		return fmt.Errorf("invalid option returned %v, want an error wrapping ErrInvalidParam", err)
+	}
+	if len(out) > 0 {
+		return fmt.Errorf("invalid option wrote %q", out)
+	}
+	return nil
+}
+
+func checkContextDefaults(r render.Renderer, c *conformance, data any) error {
+	ctx := render.WithDefaults(context.Background(),
+		render.Header(func(h render.HeaderOptions) { h.Set("X-Conformance", "context") }),
+		render.Header(func(h render.HeaderOptions) { h.Set("X-Conformance-Override", "context") }),
+	)
+	_, resolved, err := c.render(ctx, r, data,
+		render.Header(func(h render.HeaderOptions) { h.Set("X-Conformance-Override", "call") }),
+	)
+	if err != nil {
+		return err
+	}
+	if resolved == nil {
+		return errors.New("options were not resolved")
+	}
+	if got := resolved.Header().Get("X-Conformance"); got != "context" {
+		return fmt.Errorf("context default not applied: got %q", got)
+	}
+	if got := resolved.Header().Get("X-Conformance-Override"); got != "call" {
+		return fmt.Errorf("call option does not override context default: got %q", got)
+	}
+	return nil
+}
+
+func checkPrefix(r render.Renderer, c *conformance, data any) error {
+	const prefix = "#> "
+
+	plain, _, err := c.render(context.Background(), r, data)
+	if err != nil {
+		return err
+	}
+	out, resolved, err := c.render(context.Background(), r, data, render.Format(render.Prefix(prefix)))
+	if err != nil {
+		return err
+	}
+	if !isTextual(resolved) || len(plain) == 0 {
+		return nil
+	}
+	if !bytes.HasPrefix(out, []byte(strings.TrimRight(prefix, " "))) {
+		return fmt.Errorf("output %q does not start with prefix %q", out, prefix)
+	}
+	// Line breaks of the data, such as in quoted CSV fields, are not
+	// followed by the prefix, so it is only removed where present
+	lines := strings.SplitAfter(string(out), "\n")
+	for i, line := range lines {
+		if strings.HasPrefix(line, prefix) {
+			lines[i] = line[len(prefix):]
+		} else if rest := strings.TrimPrefix(line, strings.TrimRight(prefix, " ")); rest != line && strings.TrimSpace(rest) == "" {
+			lines[i] = rest
+		}
+	}
+	if got := strings.Join(lines, ""); got != string(plain) {
+		return fmt.Errorf("prefix changed the content: got %q, want %q with prefixed lines", out, plain)
+	}
+	return nil
+}
+
+func checkLineEnding(r render.Renderer, c *conformance, data any) error {
+	plain, _, err := c.render(context.Background(), r, data)
+	if err != nil {
+		return err
+	}
+	out, resolved, err := c.render(context.Background(), r, data, render.Format(render.LineEnding("\r\n")))
+	if err != nil {
+		return err
+	}
+	if !isTextual(resolved) {
+		return nil
+	}
+	// Line breaks of the data are written unchanged, so the output must
+	// only differ by line feeds written as CRLF, with at least one of them
+	// converted unless every line break already is CRLF
+	converted := 0
+	i, j := 0, 0
+	for i < len(plain) && j < len(out) {
+		if plain[i] == '\n' && out[j] == '\r' && j+1 < len(out) && out[j+1] == '\n' {
+			converted++
+			i, j = i+1, j+2
+			continue
+		}
+		if plain[i] != out[j] {
+			return fmt.Errorf("line ending changed the content at offset %d: got %q, want %q", j, out, plain)
+		}
+		i, j = i+1, j+1
+	}
+	if i != len(plain) || j != len(out) {
+		return fmt.Errorf("line ending changed the content: got %q, want %q", out, plain)
+	}
+	if converted == 0 && bytes.Count(plain, []byte("\n")) != bytes.Count(plain, []byte("\r\n")) {
+		return fmt.Errorf("no line break is CRLF in %q", out)
+	}
+	return nil
+}
+
+func checkWriterErrors(r render.Renderer, c *conformance, data any) error {
+	out, _, err := c.render(context.Background(), r, data)
+	if err != nil || len(out) == 0 {
+		return err
+	}
+	all := append([]func(*render.Options){}, c.opts...)
+	if err := r.RenderContext(context.Background(), failingWriter{}, data, all...); err == nil {
+		return errors.New("writer error was not returned")
+	}
+	return nil
+}
+
+// failingWriter is a writer that always fails.
+type failingWriter struct{}
+
+func (failingWriter) Write([]byte) (int, error) {
+	return 0, errors.New("rendertest: write failed")
+}
+
+// isTextual reports whether the content type of o is line-oriented text.
+func isTextual(o *render.Options) bool {
+	if o == nil {
+		return false
+	}
+	mediatype, _, _ := mime.ParseMediaType(o.ContentType())
+	return strings.HasPrefix(mediatype, "text/") ||
+		mediatype == "application/json" ||
+		mediatype == "application/xml" ||
+		mediatype == "application/javascript" ||
+		strings.HasSuffix(mediatype, "+json") ||
+		strings.HasSuffix(mediatype, "+xml")
+}
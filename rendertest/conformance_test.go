@@ -0,0 +1,132 @@
+// Copyright 2025 The Nanoninja Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+package rendertest
+
+import (
+	"context"
+	"encoding/xml"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/nanoninja/assert"
+	"github.com/nanoninja/render"
+	"github.com/nanoninja/render/tmpl"
+)
+
+// conformanceItem is a named type so that it can be encoded as XML.
+type conformanceItem struct {
+	XMLName xml.Name `xml:"item"`
+	Name    string   `xml:"name"`
+	Tags    []string `xml:"tag"`
+}
+
+func TestConformance_BuiltinRenderers(t *testing.T) {
+	item := conformanceItem{Name: "gopher", Tags: []string{"a", "b"}}
+
+	html := tmpl.HTML("conformance")
+	_, err := html.(*tmpl.HTMLTemplate).Parse("<ul>\n{{ range . }}<li>{{ . }}</li>\n{{ end }}</ul>\n")
+	assert.Nil(t, err)
+
+	text := tmpl.Text("conformance")
+	_, err = text.(*tmpl.TextTemplate).Parse("{{ range . }}- {{ . }}\n{{ end }}")
+	assert.Nil(t, err)
+
+	family := render.MetricFamily{
+		Name:    "requests_total",
+		Help:    "Total requests.",
+		Type:    render.CounterMetric,
+		Metrics: []render.Metric{{Labels: map[string]string{"code": "200"}, Value: 3}},
+	}
+	start := time.Date(2025, 3, 3, 9, 30, 0, 0, time.UTC)
+	event := render.Event{UID: "conformance@example.com", Summary: strings.Repeat("long, ", 20), Start: start, Stamp: start}
+
+	tests := map[string]struct {
+		renderer render.Renderer
+		opts     []ConformanceOption
+	}{
+		"Text":        {renderer: render.Text(), opts: []ConformanceOption{Samples("conformance\nsample", "\nleading", []byte("a\r\nb"), []string{"a", "b"})}},
+		"JSON":        {renderer: render.JSON(), opts: []ConformanceOption{Samples(item, map[string]any{"a": []int{1, 2}})}},
+		"JSONP":       {renderer: render.NewJSON(render.JSONConfig{Padding: "cb"}), opts: []ConformanceOption{Samples(item)}},
+		"XML":         {renderer: render.XML(), opts: []ConformanceOption{Samples(item)}},
+		"CSV":         {renderer: render.CSV(), opts: []ConformanceOption{Samples([][]string{{"a", "b"}, {"multi\nline", "d"}}, [][]string{{"\nleading"}})}},
+		"Dotenv":      {renderer: render.Dotenv(), opts: []ConformanceOption{Samples(map[string]string{"A": "multi\nline", "B": "\nleading"})}},
+		"Binary":      {renderer: render.Binary(), opts: []ConformanceOption{Samples([]byte{0x00, 0x01, 0x02})}},
+		"Error":       {renderer: render.ErrorPage(), opts: []ConformanceOption{Samples(render.ErrorData{Status: 404, Detail: "missing"})}},
+		"Buffer":      {renderer: render.Buffer(render.JSON()), opts: []ConformanceOption{Samples(item)}},
+		"Bound":       {renderer: render.Bind(render.JSON(), render.Format(render.Pretty())), opts: []ConformanceOption{Samples(item)}},
+		"Fallback":    {renderer: render.Fallback(render.Text(), nil)},
+		"Tree":        {renderer: render.Tree(), opts: []ConformanceOption{Samples(map[string]any{"a": []int{1, 2}, "b": "c"}, item)}},
+		"Logfmt":      {renderer: render.Logfmt(), opts: []ConformanceOption{Samples(map[string]any{"a": 1, "b": "x y"}, []render.KeyValue{{Key: "k", Value: "v"}}, []map[string]int{{"n": 1}, {"n": 2}})}},
+		"Metrics":     {renderer: render.Prometheus(), opts: []ConformanceOption{Samples(family)}},
+		"OpenMetrics": {renderer: render.OpenMetrics(), opts: []ConformanceOption{Samples([]render.MetricFamily{family})}},
+		"Form":        {renderer: render.Form(), opts: []ConformanceOption{Samples(map[string]any{"a": "x y", "b": []int{1, 2}}, item)}},
+		"Multipart":   {renderer: render.NewMultipart(render.MultipartConfig{Boundary: "conformance"}), opts: []ConformanceOption{Samples(map[string]any{"a": "x", "f": render.FilePart{Filename: "f.txt"}})}},
+		"ICalendar":   {renderer: render.ICalendar(), opts: []ConformanceOption{Skip(CheckPrefix), Samples(event)}},
+		"VCard":       {renderer: render.VCard(), opts: []ConformanceOption{Skip(CheckPrefix), Samples(render.Contact{FormattedName: "Gopher", Emails: []render.ContactValue{{Value: "gopher@example.com"}}})}},
+		"VCard3":      {renderer: render.NewVCard(render.VCardConfig{Version: render.VCard3}), opts: []ConformanceOption{Skip(CheckPrefix), Samples([]render.Contact{{FormattedName: "A"}, {FormattedName: "B"}})}},
+		"GeoJSON":     {renderer: render.NewGeoJSON(render.GeoJSONConfig{Precision: 6, BBox: true}), opts: []ConformanceOption{Samples(render.Point{Coordinates: render.Position{2.2945, 48.8584}}, []render.Feature{{ID: 1, Geometry: render.LineString{Coordinates: []render.Position{{0, 0}, {1, 1}}}, Properties: map[string]any{"a": 1}}})}},
+		"Terminal":    {renderer: render.NewTerminal(render.TerminalConfig{Level: render.TrueColor}), opts: []ConformanceOption{Samples("[bold]a[/]\n[red on #102030]b[/]")}},
+		"HTML":        {renderer: html, opts: []ConformanceOption{Samples([]string{"a", "b"})}},
+		"Template":    {renderer: text, opts: []ConformanceOption{Samples([]string{"a", "b"})}},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			Conformance(t, tt.renderer, tt.opts...)
+		})
+	}
+}
+
+// nonConformingRenderer ignores its context and options.
+type nonConformingRenderer struct{}
+
+func (r nonConformingRenderer) Render(w io.Writer, data any, opts ...func(*render.Options)) error {
+	return r.RenderContext(context.Background(), w, data, opts...)
+}
+
+func (nonConformingRenderer) RenderContext(_ context.Context, w io.Writer, _ any, _ ...func(*render.Options)) error {
+	_, _ = io.WriteString(w, "line one\nline two\n")
+	return nil
+}
+
+func TestConformance_DetectsViolations(t *testing.T) {
+	c := &conformance{skip: map[string]bool{}}
+	r := nonConformingRenderer{}
+
+	for _, chk := range checks {
+		t.Run(chk.name, func(t *testing.T) {
+			err := chk.run(r, c, "data")
+
+			switch chk.name {
+			case CheckRenderContext, CheckDeterministic:
+				assert.Nil(t, err)
+			case CheckPrefix, CheckLineEnding:
+				// Options are never resolved, so the content is not known to be textual.
+				assert.Nil(t, err)
+			default:
+				assert.NotNil(t, err)
+			}
+		})
+	}
+}
+
+func TestConformance_TextualChecks(t *testing.T) {
+	c := &conformance{skip: map[string]bool{}}
+
+	// ignoringRenderer resolves options but ignores format options.
+	ignoringRenderer := render.RendererFunc(func(ctx context.Context, w io.Writer, data any, opts ...func(*render.Options)) error {
+		render.NewOptions().Use(render.MimeTextPlain()).Use(opts...)
+		_, err := io.WriteString(w, strings.Repeat("line\n", 2))
+		return err
+	})
+
+	err := checkPrefix(ignoringRenderer, c, nil)
+	assert.NotNil(t, err)
+	assert.StringContains(t, err.Error(), "does not start with prefix")
+
+	err = checkLineEnding(ignoringRenderer, c, nil)
+	assert.NotNil(t, err)
+	assert.StringContains(t, err.Error(), "no line break is CRLF")
+}
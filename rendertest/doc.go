@@ -0,0 +1,24 @@
+// Package rendertest provides utilities for testing code built on the render
+// package, in the spirit of net/http/httptest.
+//
+// It offers:
+//   - Recorder: a Renderer capturing the data, resolved options and output
+//     of each call, optionally delegating to a real renderer
+//   - Golden: golden-file comparisons, refreshed with the -update flag
+//   - EqualJSON, EqualXML, EqualHTML and Equal: format-aware comparisons
+//   - AssertHeader, AssertContentType, AssertParam and AssertKey: option
+//     assertions
+//   - Loader: an in-memory tmpl.Loader recording the templates it reads
+//   - Conformance: a suite checking that a Renderer behaves like the
+//     built-in ones
+//
+// Basic usage:
+//
+//	rec := rendertest.NewRecorder(render.JSON())
+//	handler := NewHandler(rec)
+//	handler.ServeHTTP(httptest.NewRecorder(), req)
+//
+//	call := rec.Last()
+//	rendertest.AssertContentType(t, call.Options, "application/json")
+//	rendertest.Golden(t, "user.json", call.Output)
+package rendertest
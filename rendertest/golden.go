@@ -0,0 +1,74 @@
+// Copyright 2025 The Nanoninja Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+package rendertest
+
+import (
+	"bytes"
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// GoldenDir is the directory holding golden files, relative to the
+// package under test.
+var GoldenDir = "testdata"
+
+func init() {
+	// Another package may already define the conventional -update flag.
+	if flag.Lookup("update") == nil {
+		flag.Bool("update", false, "update golden files")
+	}
+}
+
+// updating reports whether golden files must be rewritten.
+func updating() bool {
+	f := flag.Lookup("update")
+	return f != nil && f.Value.String() == "true"
+}
+
+// goldenPath returns the path of the named golden file.
+// Names without an extension get the .golden extension.
+func goldenPath(name string) string {
+	if filepath.Ext(name) == "" {
+		name += ".golden"
+	}
+	return filepath.Join(GoldenDir, filepath.FromSlash(name))
+}
+
+// Golden compares got with the content of the named golden file stored
+// in GoldenDir. When the tests run with the -update flag, the file is
+// written with got instead, creating the directories as needed.
+// It reports whether the content matches.
+//
+// Example:
+//
+//	// Compared with testdata/invoice.html, refreshed by: go test -update
+//	rendertest.Golden(t, "invoice.html", rec.Last().Output)
+func Golden(t testing.TB, name string, got []byte) bool {
+	t.Helper()
+
+	path := goldenPath(name)
+	if updating() {
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatalf("golden %s: %v", name, err)
+			return false
+		}
+		if err := os.WriteFile(path, got, 0o644); err != nil {
+			t.Fatalf("golden %s: %v", name, err)
+			return false
+		}
+		return true
+	}
+	want, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("golden %s: %v (run go test -update to create it)", name, err)
+		return false
+	}
+	if !bytes.Equal(got, want) {
+		t.Errorf("golden %s mismatch:\n got: %q\nwant: %q", name, got, want)
+		return false
+	}
+	return true
+}
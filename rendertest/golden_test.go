@@ -0,0 +1,72 @@
+// Copyright 2025 The Nanoninja Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+package rendertest
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/nanoninja/assert"
+)
+
+// useGoldenDir points GoldenDir to a temporary directory for one test.
+func useGoldenDir(t *testing.T) string {
+	dir := t.TempDir()
+	previous := GoldenDir
+	GoldenDir = dir
+	t.Cleanup(func() { GoldenDir = previous })
+	return dir
+}
+
+// setUpdate sets the -update flag for one test.
+func setUpdate(t *testing.T, value string) {
+	previous := flag.Lookup("update").Value.String()
+	assert.Nil(t, flag.Set("update", value))
+	t.Cleanup(func() { _ = flag.Set("update", previous) })
+}
+
+func TestGolden(t *testing.T) {
+	t.Run("MatchesGoldenFile", func(t *testing.T) {
+		dir := useGoldenDir(t)
+		assert.Nil(t, os.WriteFile(filepath.Join(dir, "page.html"), []byte("<h1>Hi</h1>"), 0o600))
+
+		tb := &fakeTB{}
+
+		assert.True(t, Golden(tb, "page.html", []byte("<h1>Hi</h1>")))
+		assert.Len(t, tb.errors, 0)
+	})
+
+	t.Run("ReportsMismatch", func(t *testing.T) {
+		dir := useGoldenDir(t)
+		assert.Nil(t, os.WriteFile(filepath.Join(dir, "user.golden"), []byte("old"), 0o600))
+
+		tb := &fakeTB{}
+
+		assert.False(t, Golden(tb, "user", []byte("new")))
+		assert.Len(t, tb.errors, 1)
+		assert.False(t, tb.fatal)
+	})
+
+	t.Run("FailsWhenFileIsMissing", func(t *testing.T) {
+		useGoldenDir(t)
+		tb := &fakeTB{}
+
+		assert.False(t, Golden(tb, "missing", []byte("content")))
+		assert.True(t, tb.fatal)
+		assert.StringContains(t, tb.errors[0], "go test -update")
+	})
+
+	t.Run("UpdateWritesGoldenFile", func(t *testing.T) {
+		dir := useGoldenDir(t)
+		setUpdate(t, "true")
+
+		assert.True(t, Golden(&fakeTB{}, "nested/report", []byte("content")))
+
+		content, err := os.ReadFile(filepath.Join(dir, "nested", "report.golden"))
+		assert.Nil(t, err)
+		assert.Equals(t, string(content), "content")
+	})
+}
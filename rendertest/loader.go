@@ -0,0 +1,101 @@
+// Copyright 2025 The Nanoninja Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+package rendertest
+
+import (
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/nanoninja/render/tmpl"
+)
+
+// Loader is an in-memory tmpl.Loader for tests. It serves the templates
+// of its map, records the names it reads and can simulate failures.
+// A Loader is safe for concurrent use once configured.
+type Loader struct {
+	// Templates maps template names to their content.
+	Templates map[string]string
+
+	// Ext is the extension returned by Extension. When set, Load only
+	// returns the templates having this extension.
+	Ext string
+
+	// LoadErr, when set, is returned by Load.
+	LoadErr error
+
+	// ReadErrs maps template names to the error returned when reading them.
+	ReadErrs map[string]error
+
+	mu    sync.Mutex
+	reads []string
+}
+
+// NewLoader creates a Loader serving the given templates.
+//
+// Example:
+//
+//	src := rendertest.NewLoader(map[string]string{
+//	    "page.html": "<h1>{{ .Title }}</h1>",
+//	})
+//	t := tmpl.HTML("test", tmpl.LoadHTML(src))
+func NewLoader(templates map[string]string) *Loader {
+	return &Loader{Templates: templates}
+}
+
+// Load returns the sorted names of the templates matching pattern.
+// An empty pattern matches all templates.
+func (l *Loader) Load(pattern string) ([]string, error) {
+	if l.LoadErr != nil {
+		return nil, l.LoadErr
+	}
+	var names []string
+	for name := range l.Templates {
+		if l.Ext != "" && !strings.HasSuffix(name, l.Ext) {
+			continue
+		}
+		if pattern != "" {
+			matched, err := path.Match(pattern, name)
+			if err != nil {
+				return nil, err
+			}
+			if !matched {
+				continue
+			}
+		}
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+// Read returns the content of the named template and records the read.
+func (l *Loader) Read(name string) ([]byte, error) {
+	l.mu.Lock()
+	l.reads = append(l.reads, name)
+	l.mu.Unlock()
+
+	if err := l.ReadErrs[name]; err != nil {
+		return nil, err
+	}
+	content, ok := l.Templates[name]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", tmpl.ErrTemplateNotFound, name)
+	}
+	return []byte(content), nil
+}
+
+// Extension returns the configured template extension.
+func (l *Loader) Extension() string {
+	return l.Ext
+}
+
+// Reads returns the names of the templates read so far, in order.
+func (l *Loader) Reads() []string {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return append([]string(nil), l.reads...)
+}
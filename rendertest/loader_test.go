@@ -0,0 +1,80 @@
+// Copyright 2025 The Nanoninja Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+package rendertest
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/nanoninja/assert"
+	"github.com/nanoninja/render"
+	"github.com/nanoninja/render/tmpl"
+)
+
+var _ tmpl.Loader = (*Loader)(nil)
+
+func TestLoader(t *testing.T) {
+	templates := map[string]string{
+		"b.html":      "<b>{{ . }}</b>",
+		"a.html":      "<i>{{ . }}</i>",
+		"partial.txt": "text",
+	}
+
+	t.Run("LoadsSortedNames", func(t *testing.T) {
+		names, err := NewLoader(templates).Load("")
+
+		assert.Nil(t, err)
+		assert.Equals(t, names, []string{"a.html", "b.html", "partial.txt"})
+	})
+
+	t.Run("FiltersByExtensionAndPattern", func(t *testing.T) {
+		l := &Loader{Templates: templates, Ext: ".html"}
+
+		names, err := l.Load("b*")
+
+		assert.Nil(t, err)
+		assert.Equals(t, names, []string{"b.html"})
+		assert.Equals(t, l.Extension(), ".html")
+	})
+
+	t.Run("ReadsAndRecordsTemplates", func(t *testing.T) {
+		l := NewLoader(templates)
+
+		content, err := l.Read("a.html")
+		assert.Nil(t, err)
+		assert.Equals(t, string(content), "<i>{{ . }}</i>")
+
+		_, err = l.Read("missing.html")
+		assert.ErrorIs(t, err, tmpl.ErrTemplateNotFound)
+		assert.Equals(t, l.Reads(), []string{"a.html", "missing.html"})
+	})
+
+	t.Run("SimulatesErrors", func(t *testing.T) {
+		expectedErr := errors.New("boom")
+		l := &Loader{
+			Templates: templates,
+			LoadErr:   expectedErr,
+			ReadErrs:  map[string]error{"a.html": expectedErr},
+		}
+
+		_, err := l.Load("")
+		assert.ErrorIs(t, err, expectedErr)
+
+		_, err = l.Read("a.html")
+		assert.ErrorIs(t, err, expectedErr)
+	})
+
+	t.Run("WorksWithTemplates", func(t *testing.T) {
+		var w bytes.Buffer
+
+		l := &Loader{Templates: templates, Ext: ".html"}
+		renderer := tmpl.HTML("test", tmpl.LoadHTML(l))
+
+		err := renderer.Render(&w, "hi", render.Name("b.html"))
+
+		assert.Nil(t, err)
+		assert.Equals(t, w.String(), "<b>hi</b>")
+	})
+}
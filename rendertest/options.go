@@ -0,0 +1,101 @@
+// Copyright 2025 The Nanoninja Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+package rendertest
+
+import (
+	"mime"
+	"reflect"
+	"testing"
+
+	"github.com/nanoninja/render"
+)
+
+// AssertHeader reports whether the header key of o holds want.
+// An empty want asserts that the header is not set.
+func AssertHeader(t testing.TB, o *render.Options, key, want string) bool {
+	t.Helper()
+
+	if o == nil {
+		t.Errorf("header %s: options not captured", key)
+		return false
+	}
+	if got := o.Header().Get(key); got != want {
+		t.Errorf("header %s = %q, want %q", key, got, want)
+		return false
+	}
+	return true
+}
+
+// AssertContentType reports whether the Content-Type of o matches want.
+// Media types are compared after parsing, so that differences in case or
+// parameter formatting are ignored. When want has no parameters, only the
+// media type is compared.
+//
+// Example:
+//
+//	rendertest.AssertContentType(t, call.Options, "text/plain")
+//	rendertest.AssertContentType(t, call.Options, "text/plain; charset=utf-8")
+func AssertContentType(t testing.TB, o *render.Options, want string) bool {
+	t.Helper()
+
+	if o == nil {
+		t.Errorf("content type: options not captured")
+		return false
+	}
+	got := o.ContentType()
+	gotType, gotParams, err := mime.ParseMediaType(got)
+	if err != nil {
+		t.Errorf("content type %q: %v", got, err)
+		return false
+	}
+	wantType, wantParams, err := mime.ParseMediaType(want)
+	if err != nil {
+		t.Errorf("expected content type %q: %v", want, err)
+		return false
+	}
+	if gotType != wantType || (len(wantParams) > 0 && !reflect.DeepEqual(gotParams, wantParams)) {
+		t.Errorf("content type = %q, want %q", got, want)
+		return false
+	}
+	return true
+}
+
+// AssertParam reports whether the parameter key of o holds want.
+func AssertParam(t testing.TB, o *render.Options, key, want string) bool {
+	t.Helper()
+
+	if o == nil {
+		t.Errorf("param %s: options not captured", key)
+		return false
+	}
+	got, ok := o.Params()[key]
+	if !ok {
+		t.Errorf("param %s not set, want %q", key, want)
+		return false
+	}
+	if got != want {
+		t.Errorf("param %s = %q, want %q", key, got, want)
+		return false
+	}
+	return true
+}
+
+// AssertKey reports whether the typed key of o holds want.
+//
+// Example:
+//
+//	rendertest.AssertKey(t, call.Options, render.SeparatorKey, ';')
+func AssertKey[T comparable](t testing.TB, o *render.Options, key render.Key[T], want T) bool {
+	t.Helper()
+
+	if o == nil {
+		t.Errorf("key %s: options not captured", key)
+		return false
+	}
+	if got := key.Get(o); got != want {
+		t.Errorf("key %s = %v, want %v", key, got, want)
+		return false
+	}
+	return true
+}
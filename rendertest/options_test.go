@@ -0,0 +1,59 @@
+// Copyright 2025 The Nanoninja Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+package rendertest
+
+import (
+	"testing"
+
+	"github.com/nanoninja/assert"
+	"github.com/nanoninja/render"
+)
+
+func TestAssertions(t *testing.T) {
+	opts := render.NewOptions().Use(
+		render.MimeUTF8("text/plain"),
+		render.Param("region", "eu"),
+		render.NoSniff(),
+		render.Separator(";"),
+	)
+
+	t.Run("AssertHeader", func(t *testing.T) {
+		tb := &fakeTB{}
+
+		assert.True(t, AssertHeader(tb, opts, "X-Content-Type-Options", "nosniff"))
+		assert.True(t, AssertHeader(tb, opts, "Cache-Control", ""))
+		assert.False(t, AssertHeader(tb, opts, "X-Content-Type-Options", "sniff"))
+		assert.False(t, AssertHeader(tb, nil, "X-Content-Type-Options", "nosniff"))
+		assert.Len(t, tb.errors, 2)
+	})
+
+	t.Run("AssertContentType", func(t *testing.T) {
+		tb := &fakeTB{}
+
+		assert.True(t, AssertContentType(tb, opts, "text/plain"))
+		assert.True(t, AssertContentType(tb, opts, "Text/Plain; Charset=utf-8"))
+		assert.False(t, AssertContentType(tb, opts, "text/plain; charset=iso-8859-1"))
+		assert.False(t, AssertContentType(tb, opts, "application/json"))
+		assert.Len(t, tb.errors, 2)
+	})
+
+	t.Run("AssertParam", func(t *testing.T) {
+		tb := &fakeTB{}
+
+		assert.True(t, AssertParam(tb, opts, "region", "eu"))
+		assert.False(t, AssertParam(tb, opts, "region", "us"))
+		assert.False(t, AssertParam(tb, opts, "zone", ""))
+		assert.Len(t, tb.errors, 2)
+	})
+
+	t.Run("AssertKey", func(t *testing.T) {
+		tb := &fakeTB{}
+
+		assert.True(t, AssertKey(tb, opts, render.SeparatorKey, ';'))
+		assert.True(t, AssertKey(tb, opts, render.RangeKey, ""))
+		assert.False(t, AssertKey(tb, opts, render.SeparatorKey, ','))
+		assert.False(t, AssertKey(tb, nil, render.SeparatorKey, ';'))
+		assert.Len(t, tb.errors, 2)
+	})
+}
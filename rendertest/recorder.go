@@ -0,0 +1,104 @@
+// Copyright 2025 The Nanoninja Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+package rendertest
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"sync"
+
+	"github.com/nanoninja/render"
+)
+
+// Call describes a single rendering recorded by a Recorder.
+type Call struct {
+	Data    any             // Data passed to the renderer
+	Options *render.Options // Options resolved by the renderer
+	Output  []byte          // Content written by the renderer
+	Err     error           // Error returned by the renderer
+}
+
+// Recorder is a Renderer recording every call made to it.
+// It delegates to the wrapped renderer, if any, while capturing the data,
+// the resolved options and the written output. A Recorder is safe for
+// concurrent use.
+type Recorder struct {
+	renderer render.Renderer
+	mu       sync.Mutex
+	calls    []Call
+}
+
+// NewRecorder creates a Recorder delegating to r. When r is nil, the
+// recorder writes nothing and only resolves the options of each call.
+//
+// Example:
+//
+//	rec := rendertest.NewRecorder(render.JSON())
+//	rec.Render(w, user, render.Format(render.Pretty()))
+//	fmt.Println(string(rec.Last().Output))
+func NewRecorder(r render.Renderer) *Recorder {
+	return &Recorder{renderer: r}
+}
+
+// Render records a rendering using a background context.
+func (r *Recorder) Render(w io.Writer, data any, opts ...func(*render.Options)) error {
+	return r.RenderContext(context.Background(), w, data, opts...)
+}
+
+// RenderContext records a rendering with context support.
+// The options are captured as resolved by the wrapped renderer. When the
+// renderer does not resolve them, for instance because it failed early,
+// they are resolved by the recorder itself.
+func (r *Recorder) RenderContext(ctx context.Context, w io.Writer, data any, opts ...func(*render.Options)) error {
+	var (
+		output   bytes.Buffer
+		resolved *render.Options
+		err      error
+	)
+	if r.renderer != nil {
+		out := io.Writer(&output)
+		if w != nil {
+			out = io.MultiWriter(w, &output)
+		}
+		opts = append(opts[:len(opts):len(opts)], render.CaptureOptions(&resolved))
+		err = r.renderer.RenderContext(ctx, out, data, opts...)
+	}
+	if resolved == nil {
+		resolved = render.NewOptions().Use(opts...)
+	}
+	r.mu.Lock()
+	r.calls = append(r.calls, Call{
+		Data:    data,
+		Options: resolved,
+		Output:  output.Bytes(),
+		Err:     err,
+	})
+	r.mu.Unlock()
+	return err
+}
+
+// Calls returns a copy of the recorded calls in order.
+func (r *Recorder) Calls() []Call {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]Call(nil), r.calls...)
+}
+
+// Last returns the most recent call, or a zero Call if none was recorded.
+func (r *Recorder) Last() Call {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if len(r.calls) == 0 {
+		return Call{}
+	}
+	return r.calls[len(r.calls)-1]
+}
+
+// Reset discards all recorded calls.
+func (r *Recorder) Reset() {
+	r.mu.Lock()
+	r.calls = nil
+	r.mu.Unlock()
+}
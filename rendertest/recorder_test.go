@@ -0,0 +1,78 @@
+// Copyright 2025 The Nanoninja Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+package rendertest
+
+import (
+	"bytes"
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/nanoninja/assert"
+	"github.com/nanoninja/render"
+)
+
+var _ render.Renderer = (*Recorder)(nil)
+
+func TestRecorder(t *testing.T) {
+	t.Run("RecordsDelegatedRendering", func(t *testing.T) {
+		var w bytes.Buffer
+
+		rec := NewRecorder(render.JSON())
+		data := map[string]int{"a": 1}
+
+		err := rec.Render(&w, data, render.Format(render.Pretty()))
+
+		assert.Nil(t, err)
+		call := rec.Last()
+		assert.Equals(t, call.Data, any(data))
+		assert.Equals(t, string(call.Output), w.String())
+		assert.True(t, call.Options.Format().Pretty())
+		AssertContentType(t, call.Options, "application/json")
+		EqualJSON(t, call.Output, []byte(`{"a":1}`))
+	})
+
+	t.Run("RecordsErrors", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		rec := NewRecorder(render.Text())
+
+		err := rec.RenderContext(ctx, nil, "hello", render.Name("page"))
+
+		assert.ErrorIs(t, err, context.Canceled)
+		assert.ErrorIs(t, rec.Last().Err, context.Canceled)
+		assert.Equals(t, rec.Last().Options.Name(), "page")
+	})
+
+	t.Run("ResolvesOptionsWithoutRenderer", func(t *testing.T) {
+		rec := NewRecorder(nil)
+
+		err := rec.Render(nil, "data", render.Param("key", "value"))
+
+		assert.Nil(t, err)
+		assert.Equals(t, len(rec.Last().Output), 0)
+		AssertParam(t, rec.Last().Options, "key", "value")
+	})
+
+	t.Run("RecordsConcurrentCalls", func(t *testing.T) {
+		var wg sync.WaitGroup
+
+		rec := NewRecorder(render.Text())
+		for i := 0; i < 10; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				_ = rec.Render(nil, "x")
+			}()
+		}
+		wg.Wait()
+
+		assert.Len(t, rec.Calls(), 10)
+
+		rec.Reset()
+		assert.Len(t, rec.Calls(), 0)
+		assert.Nil(t, rec.Last().Options)
+	})
+}
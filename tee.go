@@ -0,0 +1,205 @@
+// Copyright 2025 The Nanoninja Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+package render
+
+import (
+	"context"
+	"fmt"
+	"io"
+)
+
+// TeePolicy defines how a TeeRenderer reacts to a failing destination.
+type TeePolicy int
+
+const (
+	// FailFast aborts the whole rendering as soon as the destination fails.
+	FailFast TeePolicy = iota
+
+	// BestEffort stops writing to the failing destination, reports the
+	// error through TeeTarget.OnError and keeps rendering to the others.
+	BestEffort
+)
+
+// TeeTarget describes an additional destination of a TeeRenderer.
+type TeeTarget struct {
+	// Writer receives a copy of the rendered content.
+	Writer io.Writer
+
+	// Policy defines how failures of this destination are handled.
+	// The default is FailFast.
+	Policy TeePolicy
+
+	// Transformers are applied to the content of this destination only,
+	// in the same way as a Pipeline.
+	Transformers []Transformer
+
+	// OnError is called when a BestEffort destination fails.
+	OnError func(err error)
+}
+
+// TeeRenderer renders content once and fans it out to several writers.
+// The writer given to Render is always the first destination and uses
+// the FailFast policy.
+type TeeRenderer struct {
+	renderer Renderer    // The underlying renderer producing content
+	targets  []TeeTarget // Additional destinations
+}
+
+// Tee creates a TeeRenderer copying the output of r to the given targets.
+//
+// Example:
+//
+//	// Send an invoice to the client while archiving it to disk
+//	renderer := render.Tee(invoice, render.TeeTarget{
+//	    Writer: archive,
+//	    Policy: render.BestEffort,
+//	    OnError: func(err error) { log.Printf("archive: %v", err) },
+//	})
+//	renderer.Render(w, data)
+func Tee(r Renderer, targets ...TeeTarget) *TeeRenderer {
+	return &TeeRenderer{
+		renderer: r,
+		targets:  targets,
+	}
+}
+
+// Render implements tee rendering using a background context.
+// See RenderContext for details on the rendering process.
+func (r *TeeRenderer) Render(w io.Writer, data any, opts ...func(*Options)) error {
+	return r.RenderContext(context.Background(), w, data, opts...)
+}
+
+// RenderContext implements tee rendering with context support.
+// The rendering process follows these steps:
+// 1. Builds the transformer chain of each destination
+// 2. Renders content once, copying every write to all active destinations
+// 3. Closes the transformer chains so pending output is flushed
+//
+// Every stage of every destination is closed, even when rendering or
+// closing another stage fails, so that resources held by the stages are
+// released. The errors of FailFast destinations are then returned together.
+//
+// When the context is done, writing stops for all destinations and the
+// targets supporting CloseWithError, such as io.PipeWriter, are closed with
+// the context error so that their readers are released. The writer given
+// to Render is left to the caller.
+func (r *TeeRenderer) RenderContext(ctx context.Context, w io.Writer, data any, opts ...func(*Options)) error {
+	if err := CheckContext(ctx); err != nil {
+		return err
+	}
+	tw := &teeWriter{ctx: ctx}
+	if w != nil {
+		tw.add(TeeTarget{Writer: w}, true)
+	}
+	for _, target := range r.targets {
+		tw.add(target, false)
+	}
+	var renderErr error
+	if err := r.renderer.RenderContext(ctx, tw, data, opts...); err != nil {
+		renderErr = fmt.Errorf("tee render: %w", err)
+		// Release the readers before closing stages that may write to them
+		if ctxErr := CheckContext(ctx); ctxErr != nil {
+			tw.abort(ctxErr)
+		}
+	}
+	return joinErrors(renderErr, tw.close())
+}
+
+// teeDestination is a destination with its transformation stages.
+type teeDestination struct {
+	target  TeeTarget
+	head    io.Writer        // Entry point of the transformer chain
+	stages  []io.WriteCloser // Stages to close once rendering is done
+	failed  bool             // Whether a BestEffort destination has failed
+	primary bool             // Whether the writer belongs to the caller
+}
+
+// teeWriter duplicates writes to all active destinations.
+type teeWriter struct {
+	ctx          context.Context
+	destinations []*teeDestination
+}
+
+// add registers a destination and builds its transformer chain.
+// The primary destination is the writer given to Render.
+func (t *teeWriter) add(target TeeTarget, primary bool) {
+	d := &teeDestination{
+		target:  target,
+		stages:  make([]io.WriteCloser, len(target.Transformers)),
+		primary: primary,
+	}
+	next := target.Writer
+	for i := len(target.Transformers) - 1; i >= 0; i-- {
+		d.stages[i] = target.Transformers[i](next)
+		next = d.stages[i]
+	}
+	d.head = next
+	t.destinations = append(t.destinations, d)
+}
+
+func (t *teeWriter) Write(p []byte) (int, error) {
+	if err := CheckContext(t.ctx); err != nil {
+		return 0, err
+	}
+	for i, d := range t.destinations {
+		if d.failed {
+			continue
+		}
+		n, err := d.head.Write(p)
+		if err == nil && n < len(p) {
+			err = io.ErrShortWrite
+		}
+		if err != nil {
+			if err := t.fail(i, d, err); err != nil {
+				return 0, err
+			}
+		}
+	}
+	return len(p), nil
+}
+
+// fail applies the destination policy to err. It returns a non-nil error
+// when the rendering must be aborted.
+func (t *teeWriter) fail(i int, d *teeDestination, err error) error {
+	if d.target.Policy != BestEffort {
+		return fmt.Errorf("tee destination %d: %w", i, err)
+	}
+	d.failed = true
+	if d.target.OnError != nil {
+		d.target.OnError(err)
+	}
+	return nil
+}
+
+// close flushes the transformer chains of all destinations. Every stage is
+// closed; the errors of FailFast destinations are returned together while
+// BestEffort destinations report their first error through OnError.
+func (t *teeWriter) close() error {
+	var errs []error
+	for i, d := range t.destinations {
+		for _, stage := range d.stages {
+			err := stage.Close()
+			if err == nil || d.failed {
+				continue
+			}
+			if err := t.fail(i, d, err); err != nil {
+				errs = append(errs, err)
+			}
+		}
+	}
+	return joinErrors(errs...)
+}
+
+// abort propagates err to the targets able to receive it. The primary
+// writer is not closed since it belongs to the caller.
+func (t *teeWriter) abort(err error) {
+	for _, d := range t.destinations {
+		if d.primary {
+			continue
+		}
+		if c, ok := d.target.Writer.(interface{ CloseWithError(error) error }); ok {
+			_ = c.CloseWithError(err)
+		}
+	}
+}
@@ -0,0 +1,242 @@
+// Copyright 2025 The Nanoninja Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+package render
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/nanoninja/assert"
+)
+
+var (
+	_ Renderer = Tee(nil)
+	_ Renderer = (*TeeRenderer)(nil)
+)
+
+func TestTeeRenderer(t *testing.T) {
+	t.Run("WritesToAllDestinations", func(t *testing.T) {
+		var w, archive, audit bytes.Buffer
+
+		renderer := Tee(Text(),
+			TeeTarget{Writer: &archive},
+			TeeTarget{Writer: &audit},
+		)
+
+		err := renderer.Render(&w, "invoice")
+
+		assert.Nil(t, err)
+		assert.Equals(t, w.String(), "invoice")
+		assert.Equals(t, archive.String(), "invoice")
+		assert.Equals(t, audit.String(), "invoice")
+	})
+
+	t.Run("RendersOnce", func(t *testing.T) {
+		var calls int
+
+		renderer := Tee(RendererFunc(func(_ context.Context, w io.Writer, _ any, _ ...func(*Options)) error {
+			calls++
+			_, err := io.WriteString(w, "x")
+			return err
+		}), TeeTarget{Writer: io.Discard}, TeeTarget{Writer: io.Discard})
+
+		err := renderer.Render(io.Discard, nil)
+
+		assert.Nil(t, err)
+		assert.Equals(t, calls, 1)
+	})
+
+	t.Run("AllowsNilPrimaryWriter", func(t *testing.T) {
+		var archive bytes.Buffer
+
+		err := Tee(Text(), TeeTarget{Writer: &archive}).Render(nil, "invoice")
+
+		assert.Nil(t, err)
+		assert.Equals(t, archive.String(), "invoice")
+	})
+
+	t.Run("AppliesPerDestinationTransformers", func(t *testing.T) {
+		var w, archive bytes.Buffer
+
+		renderer := Tee(Text(), TeeTarget{
+			Writer: &archive,
+			Transformers: []Transformer{
+				upperTransformer,
+				func(w io.Writer) io.WriteCloser { return gzip.NewWriter(w) },
+			},
+		})
+
+		err := renderer.Render(&w, "invoice")
+		assert.Nil(t, err)
+		assert.Equals(t, w.String(), "invoice")
+
+		zr, err := gzip.NewReader(&archive)
+		assert.Nil(t, err)
+
+		content, err := io.ReadAll(zr)
+		assert.Nil(t, err)
+		assert.Equals(t, string(content), "INVOICE")
+	})
+
+	t.Run("FailFastAbortsRendering", func(t *testing.T) {
+		var w bytes.Buffer
+
+		renderer := Tee(Text(), TeeTarget{Writer: &errorWriterTest{}})
+
+		err := renderer.Render(&w, "invoice")
+
+		assert.NotNil(t, err)
+		assert.StringContains(t, err.Error(), "tee destination 1")
+	})
+
+	t.Run("BestEffortContinuesRendering", func(t *testing.T) {
+		var w, audit bytes.Buffer
+		var reported error
+
+		renderer := Tee(Text(),
+			TeeTarget{
+				Writer:  &errorWriterTest{},
+				Policy:  BestEffort,
+				OnError: func(err error) { reported = err },
+			},
+			TeeTarget{Writer: &audit},
+		)
+
+		err := renderer.Render(&w, "invoice")
+
+		assert.Nil(t, err)
+		assert.NotNil(t, reported)
+		assert.Equals(t, w.String(), "invoice")
+		assert.Equals(t, audit.String(), "invoice")
+	})
+
+	t.Run("BestEffortReportsCloseErrors", func(t *testing.T) {
+		var reported error
+
+		expectedErr := errors.New("close error")
+		renderer := Tee(Text(), TeeTarget{
+			Writer: io.Discard,
+			Policy: BestEffort,
+			Transformers: []Transformer{
+				PostRenderFunc(func([]byte) ([]byte, error) { return nil, expectedErr }),
+			},
+			OnError: func(err error) { reported = err },
+		})
+
+		err := renderer.Render(io.Discard, "invoice")
+
+		assert.Nil(t, err)
+		assert.ErrorIs(t, reported, expectedErr)
+	})
+
+	t.Run("FailFastReportsCloseErrors", func(t *testing.T) {
+		expectedErr := errors.New("close error")
+		renderer := Tee(Text(), TeeTarget{
+			Writer: io.Discard,
+			Transformers: []Transformer{
+				PostRenderFunc(func([]byte) ([]byte, error) { return nil, expectedErr }),
+			},
+		})
+
+		err := renderer.Render(io.Discard, "invoice")
+
+		assert.ErrorIs(t, err, expectedErr)
+	})
+
+	t.Run("ClosesEveryStageWhenRenderingFails", func(t *testing.T) {
+		var closed int
+
+		stage := func(w io.Writer) io.WriteCloser {
+			return &funcWriteCloser{write: w.Write, close: func() error {
+				closed++
+				return nil
+			}}
+		}
+		renderErr := errors.New("render error")
+		renderer := Tee(&mockRenderer{err: renderErr},
+			TeeTarget{Writer: io.Discard, Transformers: []Transformer{stage}},
+			TeeTarget{Writer: io.Discard, Policy: BestEffort, Transformers: []Transformer{stage, stage}},
+		)
+
+		err := renderer.Render(io.Discard, nil)
+
+		assert.ErrorIs(t, err, renderErr)
+		assert.Equals(t, closed, 3)
+	})
+
+	t.Run("ClosesEveryDestinationWhenOneFails", func(t *testing.T) {
+		var closed int
+
+		stage := func(err error) Transformer {
+			return func(w io.Writer) io.WriteCloser {
+				return &funcWriteCloser{write: w.Write, close: func() error {
+					closed++
+					return err
+				}}
+			}
+		}
+		firstErr, secondErr := errors.New("first close error"), errors.New("second close error")
+		renderer := Tee(Text(),
+			TeeTarget{Writer: io.Discard, Transformers: []Transformer{stage(firstErr), stage(nil)}},
+			TeeTarget{Writer: io.Discard, Transformers: []Transformer{stage(secondErr)}},
+		)
+
+		err := renderer.Render(io.Discard, "invoice")
+
+		assert.ErrorIs(t, err, firstErr)
+		assert.ErrorIs(t, err, secondErr)
+		assert.StringContains(t, err.Error(), "tee destination 2")
+		assert.Equals(t, closed, 3)
+	})
+
+	t.Run("RespectContextCancellation", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		err := Tee(Text()).RenderContext(ctx, io.Discard, "invoice")
+
+		assert.ErrorIs(t, err, context.Canceled)
+	})
+
+	t.Run("PropagatesCancellationToDestinations", func(t *testing.T) {
+		pr, pw := io.Pipe()
+		ctx, cancel := context.WithCancel(context.Background())
+
+		renderer := Tee(&mockRenderer{content: "invoice", beforeWrite: cancel}, TeeTarget{Writer: pw})
+
+		done := make(chan error, 1)
+		go func() {
+			_, err := io.ReadAll(pr)
+			done <- err
+		}()
+
+		err := renderer.RenderContext(ctx, io.Discard, nil)
+
+		assert.ErrorIs(t, err, context.Canceled)
+		assert.ErrorIs(t, <-done, context.Canceled)
+	})
+
+	t.Run("LeavesPrimaryWriterOpenOnCancellation", func(t *testing.T) {
+		pr, pw := io.Pipe()
+		ctx, cancel := context.WithCancel(context.Background())
+
+		renderer := Tee(&mockRenderer{content: "invoice", beforeWrite: cancel})
+
+		err := renderer.RenderContext(ctx, pw, nil)
+		assert.ErrorIs(t, err, context.Canceled)
+
+		go func() {
+			_, _ = io.WriteString(pw, "still open")
+			_ = pw.Close()
+		}()
+		content, err := io.ReadAll(pr)
+
+		assert.Nil(t, err)
+		assert.Equals(t, string(content), "still open")
+	})
+}
@@ -0,0 +1,595 @@
+// Copyright 2025 The Nanoninja Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+package render
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// ColorLevel defines the colors supported by a terminal.
+type ColorLevel int
+
+const (
+	// NoColor disables all styles, writing plain text.
+	NoColor ColorLevel = iota
+
+	// Colors16 supports the 8 standard colors and their bright variants.
+	Colors16
+
+	// Colors256 supports the 256 colors palette of xterm.
+	Colors256
+
+	// TrueColor supports 24-bit RGB colors.
+	TrueColor
+)
+
+// String returns the name of the level.
+func (l ColorLevel) String() string {
+	switch l {
+	case NoColor:
+		return "none"
+	case Colors16:
+		return "16"
+	case Colors256:
+		return "256"
+	case TrueColor:
+		return "truecolor"
+	}
+	return "ColorLevel(" + strconv.Itoa(int(l)) + ")"
+}
+
+// ColorLevelKey holds the color level used by the Terminal renderer,
+// overriding its configuration and detection.
+var ColorLevelKey = NewKey("terminal.colors", NoColor)
+
+// Colors returns an option function forcing the color level of the
+// Terminal renderer, for instance from a --color command line flag.
+//
+// Example:
+//
+//	render.Terminal().Render(os.Stdout, "[green]ok[/]", render.Colors(render.Colors256))
+func Colors(level ColorLevel) func(*Options) {
+	return Set(ColorLevelKey, level)
+}
+
+// DetectColorLevel returns the color level supported by w, following
+// common conventions:
+//   - NO_COLOR set to a non-empty value disables colors
+//   - FORCE_COLOR forces colors even when w is not a terminal: "0" or
+//     "false" disables them, "1", "2" and "3" select 16, 256 and true
+//     colors, other values detect the level from the environment
+//   - writers other than terminals, such as files, pipes and buffers,
+//     get no colors
+//   - TERM=dumb gets no colors, COLORTERM=truecolor or 24bit gets true
+//     colors and a TERM ending in "256color" gets 256 colors
+//
+// Other terminals get 16 colors.
+func DetectColorLevel(w io.Writer) ColorLevel {
+	return detectColorLevel(isTerminal(w), os.Getenv)
+}
+
+// detectColorLevel implements DetectColorLevel for a writer that is a
+// terminal or not, with the given environment.
+func detectColorLevel(terminal bool, getenv func(string) string) ColorLevel {
+	if getenv("NO_COLOR") != "" {
+		return NoColor
+	}
+	force := getenv("FORCE_COLOR")
+	switch force {
+	case "0", "false":
+		return NoColor
+	case "1", "true":
+		return Colors16
+	case "2":
+		return Colors256
+	case "3":
+		return TrueColor
+	case "":
+		if !terminal {
+			return NoColor
+		}
+	}
+	term := getenv("TERM")
+	switch colorterm := strings.ToLower(getenv("COLORTERM")); {
+	case term == "dumb" && force == "":
+		return NoColor
+	case colorterm == "truecolor" || colorterm == "24bit":
+		return TrueColor
+	case strings.HasSuffix(term, "256color"):
+		return Colors256
+	}
+	return Colors16
+}
+
+// isTerminal reports whether w is a character device, such as a terminal.
+func isTerminal(w io.Writer) bool {
+	f, ok := w.(*os.File)
+	if !ok {
+		return false
+	}
+	info, err := f.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
+
+// colorKind defines how a Color is encoded.
+type colorKind uint8
+
+const (
+	colorDefault colorKind = iota
+	colorBasic
+	colorPalette
+	colorRGB
+)
+
+// Color is a terminal color. The zero value is the default color of
+// the terminal.
+type Color struct {
+	kind    colorKind
+	r, g, b uint8 // Index of basic and palette colors in r
+}
+
+// Standard terminal colors, supported at all color levels.
+var (
+	Black         = Color{kind: colorBasic, r: 0}
+	Red           = Color{kind: colorBasic, r: 1}
+	Green         = Color{kind: colorBasic, r: 2}
+	Yellow        = Color{kind: colorBasic, r: 3}
+	Blue          = Color{kind: colorBasic, r: 4}
+	Magenta       = Color{kind: colorBasic, r: 5}
+	Cyan          = Color{kind: colorBasic, r: 6}
+	White         = Color{kind: colorBasic, r: 7}
+	BrightBlack   = Color{kind: colorBasic, r: 8}
+	BrightRed     = Color{kind: colorBasic, r: 9}
+	BrightGreen   = Color{kind: colorBasic, r: 10}
+	BrightYellow  = Color{kind: colorBasic, r: 11}
+	BrightBlue    = Color{kind: colorBasic, r: 12}
+	BrightMagenta = Color{kind: colorBasic, r: 13}
+	BrightCyan    = Color{kind: colorBasic, r: 14}
+	BrightWhite   = Color{kind: colorBasic, r: 15}
+)
+
+// colorNames maps the names used in markup to the standard colors.
+var colorNames = map[string]Color{
+	"black": Black, "red": Red, "green": Green, "yellow": Yellow,
+	"blue": Blue, "magenta": Magenta, "cyan": Cyan, "white": White,
+	"bright-black": BrightBlack, "bright-red": BrightRed,
+	"bright-green": BrightGreen, "bright-yellow": BrightYellow,
+	"bright-blue": BrightBlue, "bright-magenta": BrightMagenta,
+	"bright-cyan": BrightCyan, "bright-white": BrightWhite,
+	"gray": BrightBlack, "grey": BrightBlack,
+}
+
+// basicRGB holds the RGB values of the standard colors, as defined by
+// xterm, used to approximate other colors at the Colors16 level.
+var basicRGB = [16][3]uint8{
+	{0, 0, 0}, {205, 0, 0}, {0, 205, 0}, {205, 205, 0},
+	{0, 0, 238}, {205, 0, 205}, {0, 205, 205}, {229, 229, 229},
+	{127, 127, 127}, {255, 0, 0}, {0, 255, 0}, {255, 255, 0},
+	{92, 92, 255}, {255, 0, 255}, {0, 255, 255}, {255, 255, 255},
+}
+
+// cubeLevels holds the channel values of the 6x6x6 color cube of the
+// 256 colors palette.
+var cubeLevels = [6]uint8{0, 95, 135, 175, 215, 255}
+
+// Palette returns the color at index n of the 256 colors palette. It is
+// approximated by the closest standard color at the Colors16 level.
+func Palette(n uint8) Color {
+	if n < 16 {
+		return Color{kind: colorBasic, r: n}
+	}
+	return Color{kind: colorPalette, r: n}
+}
+
+// RGB returns a 24-bit color. It is approximated by the closest palette
+// or standard color at lower color levels.
+func RGB(r, g, b uint8) Color {
+	return Color{kind: colorRGB, r: r, g: g, b: b}
+}
+
+// rgb returns the RGB values of c.
+func (c Color) rgb() (uint8, uint8, uint8) {
+	switch {
+	case c.kind == colorBasic:
+		v := basicRGB[c.r]
+		return v[0], v[1], v[2]
+	case c.kind == colorPalette && c.r >= 232:
+		v := 8 + 10*(c.r-232)
+		return v, v, v
+	case c.kind == colorPalette:
+		i := c.r - 16
+		return cubeLevels[i/36], cubeLevels[i/6%6], cubeLevels[i%6]
+	}
+	return c.r, c.g, c.b
+}
+
+// downgrade returns the closest color of c supported at level.
+func (c Color) downgrade(level ColorLevel) Color {
+	switch {
+	case c.kind == colorDefault || c.kind == colorBasic:
+		return c
+	case level == Colors16:
+		return nearestBasic(c.rgb())
+	case level == Colors256 && c.kind == colorRGB:
+		return Palette(nearestPalette(c.r, c.g, c.b))
+	}
+	return c
+}
+
+// nearestBasic returns the standard color closest to an RGB value.
+func nearestBasic(r, g, b uint8) Color {
+	best, dist := 0, -1
+	for i, v := range basicRGB {
+		dr, dg, db := int(r)-int(v[0]), int(g)-int(v[1]), int(b)-int(v[2])
+		if d := dr*dr + dg*dg + db*db; dist < 0 || d < dist {
+			best, dist = i, d
+		}
+	}
+	return Color{kind: colorBasic, r: uint8(best)}
+}
+
+// nearestPalette returns the index of the 256 colors palette closest to
+// an RGB value, using the grayscale ramp for grays.
+func nearestPalette(r, g, b uint8) uint8 {
+	if r == g && g == b {
+		switch {
+		case r < 8:
+			return 16
+		case r > 238:
+			return 231
+		}
+		return 232 + (r-8)/10
+	}
+	level := func(v uint8) uint8 {
+		if v < 48 {
+			return 0
+		}
+		if v < 115 {
+			return 1
+		}
+		return (v - 35) / 40
+	}
+	return 16 + 36*level(r) + 6*level(g) + level(b)
+}
+
+// sgr appends the Select Graphic Rendition parameters of c, using the
+// given base code: 30 for the foreground and 40 for the background.
+func (c Color) sgr(params []string, base int) []string {
+	switch c.kind {
+	case colorBasic:
+		if c.r < 8 {
+			return append(params, strconv.Itoa(base+int(c.r)))
+		}
+		return append(params, strconv.Itoa(base+60+int(c.r)-8))
+	case colorPalette:
+		return append(params, strconv.Itoa(base+8), "5", strconv.Itoa(int(c.r)))
+	case colorRGB:
+		return append(params, strconv.Itoa(base+8), "2",
+			strconv.Itoa(int(c.r)), strconv.Itoa(int(c.g)), strconv.Itoa(int(c.b)))
+	}
+	return params
+}
+
+// Style defines the appearance of terminal text. The zero value is
+// unstyled text.
+type Style struct {
+	Foreground Color
+	Background Color
+	Bold       bool
+	Dim        bool
+	Italic     bool
+	Underline  bool
+}
+
+// sequence returns the escape sequence applying s at level, or an empty
+// string when s has no effect.
+func (s Style) sequence(level ColorLevel) string {
+	if level <= NoColor {
+		return ""
+	}
+	var params []string
+	if s.Bold {
+		params = append(params, "1")
+	}
+	if s.Dim {
+		params = append(params, "2")
+	}
+	if s.Italic {
+		params = append(params, "3")
+	}
+	if s.Underline {
+		params = append(params, "4")
+	}
+	params = s.Foreground.downgrade(level).sgr(params, 30)
+	params = s.Background.downgrade(level).sgr(params, 40)
+	if len(params) == 0 {
+		return ""
+	}
+	return "\x1b[" + strings.Join(params, ";") + "m"
+}
+
+// styleReset is the escape sequence resetting all styles.
+const styleReset = "\x1b[0m"
+
+// Span is a piece of text displayed with a style.
+type Span struct {
+	Text  string
+	Style Style
+}
+
+// Styled returns a span displaying text with the given style. Unlike
+// markup, the text is written as-is.
+//
+// Example:
+//
+//	render.Terminal().Render(os.Stdout, []render.Span{
+//	    render.Styled("error: ", render.Style{Foreground: render.Red, Bold: true}),
+//	    render.Styled(err.Error(), render.Style{}),
+//	})
+func Styled(text string, style Style) Span {
+	return Span{Text: text, Style: style}
+}
+
+// ParseMarkup converts markup into spans. Styles are opened by a tag
+// listing style words between brackets, and closed by [/]:
+//
+//	[bold red]error:[/] file [underline]config.yaml[/] not found
+//
+// Style words are bold, dim, italic, underline, color names (black, red,
+// green, yellow, blue, magenta, cyan, white, gray and their "bright-"
+// variants), palette indexes from 0 to 255 and #rrggbb colors. A color
+// following the word "on" sets the background. Tags nest, each [/]
+// closing the innermost one.
+//
+// Bracketed text that is not a valid tag, such as "[1/2]", and [/] without
+// an open tag are written literally. A doubled bracket "[[" is written as
+// a single "[" and never opens a tag; text from users should be escaped
+// with EscapeMarkup before being inserted into markup.
+func ParseMarkup(markup string) []Span {
+	var (
+		spans []Span
+		text  strings.Builder
+		stack = []Style{{}}
+	)
+	flush := func() {
+		if text.Len() > 0 {
+			spans = append(spans, Span{Text: text.String(), Style: stack[len(stack)-1]})
+			text.Reset()
+		}
+	}
+	for len(markup) > 0 {
+		start := strings.IndexByte(markup, '[')
+		if start < 0 {
+			text.WriteString(markup)
+			break
+		}
+		text.WriteString(markup[:start])
+		markup = markup[start:]
+
+		if strings.HasPrefix(markup, "[[") {
+			text.WriteByte('[')
+			markup = markup[2:]
+			continue
+		}
+		end := strings.IndexByte(markup, ']')
+		if end < 0 {
+			text.WriteString(markup)
+			break
+		}
+		tag := markup[1:end]
+		if tag == "/" && len(stack) > 1 {
+			flush()
+			stack = stack[:len(stack)-1]
+			markup = markup[end+1:]
+			continue
+		}
+		if style, ok := parseStyle(stack[len(stack)-1], tag); ok {
+			flush()
+			stack = append(stack, style)
+			markup = markup[end+1:]
+			continue
+		}
+		text.WriteByte('[')
+		markup = markup[1:]
+	}
+	flush()
+	return spans
+}
+
+// markupEscaper doubles the opening brackets of text.
+var markupEscaper = strings.NewReplacer("[", "[[")
+
+// EscapeMarkup escapes text so that ParseMarkup writes it literally,
+// such as user data containing brackets.
+//
+// Example:
+//
+//	render.Terminal().Render(os.Stdout, "[bold]"+render.EscapeMarkup(name)+"[/] joined\n")
+func EscapeMarkup(text string) string {
+	return markupEscaper.Replace(text)
+}
+
+// parseStyle applies the style words of tag to base. It reports false
+// when tag holds a word that is not a style.
+func parseStyle(base Style, tag string) (Style, bool) {
+	words := strings.Fields(strings.ToLower(tag))
+	if len(words) == 0 {
+		return base, false
+	}
+	style := base
+	for i := 0; i < len(words); i++ {
+		switch word := words[i]; word {
+		case "bold":
+			style.Bold = true
+		case "dim":
+			style.Dim = true
+		case "italic":
+			style.Italic = true
+		case "underline":
+			style.Underline = true
+		case "on":
+			if i+1 == len(words) {
+				return base, false
+			}
+			i++
+			color, ok := parseColor(words[i])
+			if !ok {
+				return base, false
+			}
+			style.Background = color
+		default:
+			color, ok := parseColor(word)
+			if !ok {
+				return base, false
+			}
+			style.Foreground = color
+		}
+	}
+	return style, true
+}
+
+// parseColor parses a color name, palette index or #rrggbb color.
+func parseColor(s string) (Color, bool) {
+	if c, ok := colorNames[s]; ok {
+		return c, true
+	}
+	if strings.HasPrefix(s, "#") && len(s) == 7 {
+		v, err := strconv.ParseUint(s[1:], 16, 32)
+		if err != nil {
+			return Color{}, false
+		}
+		return RGB(uint8(v>>16), uint8(v>>8), uint8(v)), true
+	}
+	n, err := strconv.ParseUint(s, 10, 8)
+	if err != nil {
+		return Color{}, false
+	}
+	return Palette(uint8(n)), true
+}
+
+// TerminalConfig defines configuration options for terminal rendering.
+type TerminalConfig struct {
+	// Level is the color level of the output, used when Detect is false.
+	Level ColorLevel
+
+	// Detect enables the detection of the color level of the writer
+	// with DetectColorLevel, so that styles are stripped when writing
+	// to files, pipes or when NO_COLOR is set.
+	Detect bool
+}
+
+// terminalRenderer implements styled text rendering for terminals.
+type terminalRenderer struct {
+	config TerminalConfig
+}
+
+// Terminal creates a new terminal renderer detecting the color level of
+// the writer. This is the recommended constructor for command line tools
+// writing to os.Stdout or os.Stderr.
+func Terminal() Renderer {
+	return NewTerminal(TerminalConfig{Detect: true})
+}
+
+// NewTerminal creates a terminal renderer with custom configuration.
+// Use this when the color level is known, for instance when rendering to
+// a buffer displayed later in a terminal.
+func NewTerminal(c TerminalConfig) Renderer {
+	return &terminalRenderer{config: c}
+}
+
+// Render writes styled text to the writer using a background context.
+// It accepts the following data types:
+// - string: parsed as markup, see ParseMarkup
+// - Span and []Span: written with their styles
+// - others: written as plain text, as by the Text renderer
+func (r *terminalRenderer) Render(w io.Writer, data any, opts ...func(*Options)) error {
+	return r.RenderContext(context.Background(), w, data, opts...)
+}
+
+// RenderContext writes styled text with context support.
+// The color level is set by the Colors option, or else by the
+// configuration. Styles are written with ANSI escape sequences, closed at
+// the end of each line so that prefixes are never styled, and downgraded
+// to the closest colors supported by the level. With NoColor, plain text
+// is written.
+// The content type is text/plain by default. When pretty-printing is
+// enabled, a newline is added after the text.
+func (r *terminalRenderer) RenderContext(ctx context.Context, w io.Writer, data any, opts ...func(*Options)) error {
+	if err := CheckContext(ctx); err != nil {
+		return err
+	}
+	options := NewOptions().
+		Use(MimeTextPlain()).
+		Use(ContextDefaults(ctx)).
+		Use(opts...)
+
+	if err := options.Err(); err != nil {
+		return err
+	}
+
+	level := r.config.Level
+	if r.config.Detect {
+		level = DetectColorLevel(w)
+	}
+	if l, ok := ColorLevelKey.Lookup(options); ok {
+		level = l
+	}
+
+	var spans []Span
+	switch v := data.(type) {
+	case string:
+		spans = ParseMarkup(v)
+	case Span:
+		spans = []Span{v}
+	case []Span:
+		spans = v
+	case fmt.Stringer:
+		spans = []Span{{Text: v.String()}}
+	case error:
+		spans = []Span{{Text: v.Error()}}
+	default:
+		spans = []Span{{Text: fmt.Sprintf("%v", v)}}
+	}
+
+	out, err := OutputWriter(w, options)
+	if err != nil {
+		return err
+	}
+	var b strings.Builder
+	for _, span := range spans {
+		writeSpan(&b, span, level)
+	}
+	if options.format.pretty {
+		b.WriteString(options.format.LineEnding())
+	}
+	if _, err := io.WriteString(out, b.String()); err != nil {
+		return err
+	}
+	return out.Close()
+}
+
+// writeSpan writes the text of span, styling each of its lines
+// separately so that styles do not cross line breaks.
+func writeSpan(b *strings.Builder, span Span, level ColorLevel) {
+	seq := span.Style.sequence(level)
+	if seq == "" {
+		b.WriteString(span.Text)
+		return
+	}
+	lines := strings.SplitAfter(span.Text, "\n")
+	for _, line := range lines {
+		content := strings.TrimRight(line, "\r\n")
+		if content != "" {
+			b.WriteString(seq)
+			b.WriteString(content)
+			b.WriteString(styleReset)
+		}
+		b.WriteString(line[len(content):])
+	}
+}
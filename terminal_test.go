@@ -0,0 +1,221 @@
+// Copyright 2025 The Nanoninja Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+package render
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/nanoninja/assert"
+)
+
+var (
+	_ Renderer = (*terminalRenderer)(nil)
+	_ Renderer = Terminal()
+)
+
+func TestTerminalRenderer(t *testing.T) {
+	colors := NewTerminal(TerminalConfig{Level: TrueColor})
+
+	t.Run("RendersMarkup", func(t *testing.T) {
+		var w bytes.Buffer
+
+		err := colors.Render(&w, "[bold red]error:[/] not found")
+
+		assert.Nil(t, err)
+		assert.Equals(t, w.String(), "\x1b[1;31merror:\x1b[0m not found")
+	})
+
+	t.Run("NestsMarkupTags", func(t *testing.T) {
+		var w bytes.Buffer
+
+		err := colors.Render(&w, "[bold]a[underline]b[/]c[/]d")
+
+		assert.Nil(t, err)
+		assert.Equals(t, w.String(), "\x1b[1ma\x1b[0m\x1b[1;4mb\x1b[0m\x1b[1mc\x1b[0md")
+	})
+
+	t.Run("RendersSpans", func(t *testing.T) {
+		var w bytes.Buffer
+		data := []Span{
+			Styled("[ok]", Style{Foreground: BrightGreen, Background: Black}),
+			Styled(" done", Style{}),
+		}
+
+		err := colors.Render(&w, data)
+
+		assert.Nil(t, err)
+		assert.Equals(t, w.String(), "\x1b[92;40m[ok]\x1b[0m done")
+	})
+
+	t.Run("StripsStylesWithoutColors", func(t *testing.T) {
+		var w bytes.Buffer
+
+		err := NewTerminal(TerminalConfig{}).Render(&w, "[bold red]error:[/] [1/2] done")
+
+		assert.Nil(t, err)
+		assert.Equals(t, w.String(), "error: [1/2] done")
+	})
+
+	t.Run("DetectsNoColorsForBuffers", func(t *testing.T) {
+		var w bytes.Buffer
+
+		err := Terminal().Render(&w, "[green]ok[/]")
+
+		assert.Nil(t, err)
+		assert.Equals(t, w.String(), "ok")
+	})
+
+	t.Run("ColorsOptionOverridesLevel", func(t *testing.T) {
+		var w bytes.Buffer
+
+		err := Terminal().Render(&w, "[green]ok[/]", Colors(Colors16))
+
+		assert.Nil(t, err)
+		assert.Equals(t, w.String(), "\x1b[32mok\x1b[0m")
+	})
+
+	t.Run("DowngradesColorsToLevel", func(t *testing.T) {
+		tests := []struct {
+			name  string
+			level ColorLevel
+			want  string
+		}{
+			{"TrueColor", TrueColor, "\x1b[38;2;255;135;0mx\x1b[0m"},
+			{"Colors256", Colors256, "\x1b[38;5;208mx\x1b[0m"},
+			{"Colors16", Colors16, "\x1b[33mx\x1b[0m"},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				var w bytes.Buffer
+
+				err := colors.Render(&w, "[#ff8700]x[/]", Colors(tt.level))
+
+				assert.Nil(t, err)
+				assert.Equals(t, w.String(), tt.want)
+			})
+		}
+	})
+
+	t.Run("ClosesStylesAtLineBreaks", func(t *testing.T) {
+		var w bytes.Buffer
+
+		err := colors.Render(&w, "[blue]one\ntwo[/]", Format(Prefix("> "), LineEnding("\r\n")))
+
+		assert.Nil(t, err)
+		assert.Equals(t, w.String(), "> \x1b[34mone\x1b[0m\r\n> \x1b[34mtwo\x1b[0m")
+	})
+
+	t.Run("RendersOtherTypesAsPlainText", func(t *testing.T) {
+		var w bytes.Buffer
+
+		err := colors.Render(&w, errors.New("[bold]raw"), Format(Pretty()))
+
+		assert.Nil(t, err)
+		assert.Equals(t, w.String(), "[bold]raw\n")
+	})
+
+	t.Run("RespectsContextCancellation", func(t *testing.T) {
+		var w bytes.Buffer
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		err := colors.RenderContext(ctx, &w, "test")
+
+		assert.ErrorIs(t, err, context.Canceled)
+		assert.Equals(t, w.Len(), 0)
+	})
+}
+
+func TestParseMarkup(t *testing.T) {
+	t.Run("ParsesStyleWords", func(t *testing.T) {
+		spans := ParseMarkup("[bold dim italic underline yellow on bright-blue]x[/]")
+
+		assert.Equals(t, len(spans), 1)
+		assert.Equals(t, spans[0].Style, Style{
+			Foreground: Yellow,
+			Background: BrightBlue,
+			Bold:       true,
+			Dim:        true,
+			Italic:     true,
+			Underline:  true,
+		})
+	})
+
+	t.Run("ParsesPaletteAndRGBColors", func(t *testing.T) {
+		spans := ParseMarkup("[208 on #0a0B0c]x[/]")
+
+		assert.Equals(t, spans[0].Style.Foreground, Palette(208))
+		assert.Equals(t, spans[0].Style.Background, RGB(10, 11, 12))
+	})
+
+	t.Run("KeepsInvalidTagsLiteral", func(t *testing.T) {
+		spans := ParseMarkup("[1/2] [] [on] [300] [/] [unclosed")
+
+		assert.Equals(t, spans, []Span{{Text: "[1/2] [] [on] [300] [/] [unclosed"}})
+	})
+
+	t.Run("UnescapesDoubledBrackets", func(t *testing.T) {
+		spans := ParseMarkup("[[bold]] [bold]x[[/][/]")
+
+		assert.Equals(t, spans, []Span{
+			{Text: "[bold]] "},
+			{Text: "x[/]", Style: Style{Bold: true}},
+		})
+	})
+}
+
+func TestEscapeMarkup(t *testing.T) {
+	for _, text := range []string{"[bold]user[/]", "[[", "a]b", "plain", ""} {
+		spans := ParseMarkup("[red]" + EscapeMarkup(text) + "[/]")
+
+		if text == "" {
+			assert.Len(t, spans, 0)
+			continue
+		}
+		assert.Equals(t, spans, []Span{{Text: text, Style: Style{Foreground: Red}}})
+	}
+}
+
+func TestDetectColorLevel(t *testing.T) {
+	tests := []struct {
+		name string
+		env  map[string]string
+		tty  bool
+		want ColorLevel
+	}{
+		{"NoTerminal", nil, false, NoColor},
+		{"NoColorWins", map[string]string{"NO_COLOR": "1", "FORCE_COLOR": "3"}, false, NoColor},
+		{"EmptyNoColorIgnored", map[string]string{"NO_COLOR": "", "FORCE_COLOR": "1"}, false, Colors16},
+		{"ForceColorDisabled", map[string]string{"FORCE_COLOR": "0"}, true, NoColor},
+		{"ForceColor256", map[string]string{"FORCE_COLOR": "2"}, false, Colors256},
+		{"ForceColorTrue", map[string]string{"FORCE_COLOR": "3"}, false, TrueColor},
+		{"ForceColorDetectsLevel", map[string]string{"FORCE_COLOR": "yes", "COLORTERM": "truecolor"}, false, TrueColor},
+		{"DumbTerminal", map[string]string{"TERM": "dumb"}, true, NoColor},
+		{"TrueColorTerminal", map[string]string{"TERM": "xterm", "COLORTERM": "24bit"}, true, TrueColor},
+		{"Terminal256", map[string]string{"TERM": "xterm-256color"}, true, Colors256},
+		{"BasicTerminal", map[string]string{"TERM": "xterm"}, true, Colors16},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			getenv := func(key string) string { return tt.env[key] }
+
+			assert.Equals(t, detectColorLevel(tt.tty, getenv), tt.want)
+		})
+	}
+
+	t.Run("BuffersAreNotTerminals", func(t *testing.T) {
+		assert.False(t, isTerminal(&bytes.Buffer{}))
+	})
+}
+
+func TestColorLevelString(t *testing.T) {
+	assert.Equals(t, NoColor.String(), "none")
+	assert.Equals(t, Colors16.String(), "16")
+	assert.Equals(t, Colors256.String(), "256")
+	assert.Equals(t, TrueColor.String(), "truecolor")
+	assert.Equals(t, ColorLevel(9).String(), "ColorLevel(9)")
+}
@@ -4,14 +4,27 @@
 package render
 
 import (
+	"bytes"
 	"context"
 	"fmt"
 	"io"
+	"strings"
+)
+
+// Keys of the options specific to the Text renderer.
+var (
+	// LiteralKey disables format string handling of string data.
+	LiteralKey = NewKey("text.literal", false)
+
+	// WrapKey holds the width at which Text wraps lines, in columns.
+	// Zero disables wrapping.
+	WrapKey = NewKey("text.wrap", 0)
 )
 
 // textRenderer implements text rendering with support for various data types
-// and formatting options. It can handle strings, fmt.Stringer types, errors
-// and any other type that can be converted to string representation.
+// and formatting options. It can handle strings, byte slices, string slices,
+// readers, fmt.Stringer types, errors and any other type that can be
+// converted to string representation.
 type textRenderer struct{}
 
 // Text creates a new TextRenderer instance configured with default options.
@@ -21,11 +34,37 @@ func Text() Renderer {
 	return &textRenderer{}
 }
 
+// Literal returns an option function writing string data as-is, even when
+// format arguments are set, for instance by context defaults.
+//
+// Example:
+//
+//	render.Text().Render(w, "100% done", render.Literal())
+func Literal() func(*Options) {
+	return Set(LiteralKey, true)
+}
+
+// Wrap returns an option function wrapping the lines of text output at
+// the given width, counted in terminal columns as by DisplayWidth and
+// excluding the prefix. Lines are broken between words; a word longer
+// than the width is kept whole on its own line.
+//
+// Example:
+//
+//	render.Text().Render(w, notice, render.Wrap(72), render.Format(render.Comment("#")))
+func Wrap(width int) func(*Options) {
+	return Set(WrapKey, width)
+}
+
 // Render writes the string representation of data to the writer.
 // It converts input data to string based on its type:
-// - string: used as-is
+// - string: used as a format string when format arguments are set,
+// as-is otherwise or with the Literal option
+// - []byte: used as-is
+// - []string: joined by the line ending
 // - fmt.Stringer: String() method is called
 // - error: Error() method is called
+// - io.Reader: streamed until EOF
 // - others: fmt.Sprintf("%v") is used
 // This is a convenience method that uses a background context.
 func (r *textRenderer) Render(w io.Writer, data any, opts ...func(*Options)) error {
@@ -37,28 +76,146 @@ func (r *textRenderer) Render(w io.Writer, data any, opts ...func(*Options)) err
 // text output to the provided writer.
 // The content type defaults to text/plain but can be overridden through options.
 // When pretty-printing is enabled, a newline is added after the text.
+// Readers are streamed in chunks, checking the context between them.
+// Every line starts with the prefix, line breaks are normalized to the
+// line ending, and lines are wrapped at the width set with Wrap.
+// The output is transcoded when the content type declares a charset other
+// than UTF-8, such as Mime("text/plain", "iso-8859-1").
 func (r *textRenderer) RenderContext(ctx context.Context, w io.Writer, data any, opts ...func(*Options)) error {
 	if err := CheckContext(ctx); err != nil {
 		return err
 	}
 	options := NewOptions().
 		Use(MimeTextPlain()).
+		Use(ContextDefaults(ctx)).
 		Use(opts...)
 
-	var text string
+	if err := options.Err(); err != nil {
+		return err
+	}
+
+	var (
+		text   string
+		reader io.Reader
+	)
 	switch v := data.(type) {
 	case string:
-		text = fmt.Sprintf(v, options.format.args...)
+		text = v
+		if len(options.format.args) > 0 && !LiteralKey.Get(options) {
+			text = fmt.Sprintf(v, options.format.args...)
+		}
+	case []byte:
+		text = string(v)
+	case []string:
+		text = strings.Join(v, options.format.LineEnding())
 	case fmt.Stringer:
 		text = v.String()
 	case error:
 		text = v.Error()
+	case io.Reader:
+		reader = v
 	default:
 		text = fmt.Sprintf("%v", v)
 	}
+
+	out, err := OutputWriter(w, options)
+	if err != nil {
+		return err
+	}
+	tw := io.WriteCloser(nopWriteCloser{out})
+	if width := WrapKey.Get(options); width > 0 {
+		tw = &wrapWriter{w: out, width: width}
+	}
+	if reader != nil {
+		err = copyContext(ctx, tw, reader)
+	} else {
+		_, err = io.WriteString(tw, text)
+	}
+	if err != nil {
+		return err
+	}
+	if err := tw.Close(); err != nil {
+		return err
+	}
 	if options.format.pretty {
-		text += "\n"
+		if _, err := io.WriteString(out, options.format.LineEnding()); err != nil {
+			return err
+		}
+	}
+	return out.Close()
+}
+
+// wrapWriter wraps the lines written to it at a given width. Each line is
+// buffered until its line break, or until Close for the last one.
+type wrapWriter struct {
+	w     io.Writer
+	width int
+	line  []byte
+}
+
+func (ww *wrapWriter) Write(p []byte) (int, error) {
+	n := len(p)
+	for len(p) > 0 {
+		i := bytes.IndexByte(p, '\n')
+		if i < 0 {
+			ww.line = append(ww.line, p...)
+			break
+		}
+		ww.line = append(ww.line, p[:i+1]...)
+		p = p[i+1:]
+		if err := ww.flush(); err != nil {
+			return 0, err
+		}
+	}
+	return n, nil
+}
+
+// Close writes the last line. It does not close the underlying writer.
+func (ww *wrapWriter) Close() error {
+	return ww.flush()
+}
+
+// flush writes the buffered line, wrapped at the configured width.
+func (ww *wrapWriter) flush() error {
+	if len(ww.line) == 0 {
+		return nil
 	}
-	_, err := io.WriteString(w, text)
+	line := string(ww.line)
+	ww.line = ww.line[:0]
+
+	content := strings.TrimRight(line, "\r\n")
+	ending := line[len(content):]
+	_, err := io.WriteString(ww.w, wrapLine(content, ww.width)+ending)
 	return err
 }
+
+// wrapLine breaks line between words so that each part is at most width
+// columns wide, keeping the leading indentation on the first part.
+func wrapLine(line string, width int) string {
+	if DisplayWidth(line) <= width {
+		return line
+	}
+	words := strings.Fields(line)
+	if len(words) == 0 {
+		return line
+	}
+	indent := line[:len(line)-len(strings.TrimLeft(line, " \t"))]
+
+	var b strings.Builder
+	b.WriteString(indent)
+	b.WriteString(words[0])
+	n := DisplayWidth(indent + words[0])
+	for _, word := range words[1:] {
+		size := DisplayWidth(word)
+		if n+1+size > width {
+			b.WriteByte('\n')
+			n = 0
+		} else {
+			b.WriteByte(' ')
+			n++
+		}
+		b.WriteString(word)
+		n += size
+	}
+	return b.String()
+}
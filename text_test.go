@@ -7,7 +7,10 @@ import (
 	"bytes"
 	"context"
 	"errors"
+	"io"
+	"strings"
 	"testing"
+	"testing/iotest"
 	"time"
 
 	"github.com/nanoninja/assert"
@@ -96,4 +99,135 @@ func TestTextRenderer(t *testing.T) {
 		assert.Nil(t, err)
 		assert.Equals(t, opts.ContentType(), "text/plain; charset=utf-8")
 	})
+	t.Run("AppliesPrefixAndLineEnding", func(t *testing.T) {
+		var w bytes.Buffer
+
+		err := Text().Render(&w, "first\nsecond", Format(Prefix("> "), LineEnding("\r\n"), Pretty()))
+
+		assert.Nil(t, err)
+		assert.Equals(t, w.String(), "> first\r\n> second\r\n")
+	})
+
+	t.Run("WritesStringAsIsWithoutArguments", func(t *testing.T) {
+		var w bytes.Buffer
+
+		err := Text().Render(&w, "100% done, 50%d")
+
+		assert.Nil(t, err)
+		assert.Equals(t, w.String(), "100% done, 50%d")
+	})
+
+	t.Run("LiteralIgnoresFormatArguments", func(t *testing.T) {
+		var w bytes.Buffer
+
+		err := Text().Render(&w, "Hello, %s", Textf("Gophers"), Literal())
+
+		assert.Nil(t, err)
+		assert.Equals(t, w.String(), "Hello, %s")
+	})
+
+	t.Run("RendersByteSlices", func(t *testing.T) {
+		var w bytes.Buffer
+
+		err := Text().Render(&w, []byte("raw %s bytes"))
+
+		assert.Nil(t, err)
+		assert.Equals(t, w.String(), "raw %s bytes")
+	})
+
+	t.Run("JoinsStringSlicesWithLineEnding", func(t *testing.T) {
+		var w bytes.Buffer
+
+		err := Text().Render(&w, []string{"one", "two", "three"}, UseCRLF())
+
+		assert.Nil(t, err)
+		assert.Equals(t, w.String(), "one\r\ntwo\r\nthree")
+	})
+
+	t.Run("StreamsReaders", func(t *testing.T) {
+		var w bytes.Buffer
+		data := strings.Repeat("line of text\n", 5000)
+
+		err := Text().Render(&w, strings.NewReader(data), Format(Prefix("# ")))
+
+		assert.Nil(t, err)
+		assert.Equals(t, w.String(), strings.Repeat("# line of text\n", 5000))
+	})
+
+	t.Run("StopsStreamingWhenContextIsDone", func(t *testing.T) {
+		var w bytes.Buffer
+		ctx, cancel := context.WithCancel(context.Background())
+		reader := &cancelingReader{Reader: strings.NewReader(strings.Repeat("x", 100000)), cancel: cancel}
+
+		err := Text().RenderContext(ctx, &w, reader)
+
+		assert.ErrorIs(t, err, context.Canceled)
+		assert.True(t, w.Len() < 100000)
+	})
+
+	t.Run("ReturnsReaderErrors", func(t *testing.T) {
+		var w bytes.Buffer
+		readErr := errors.New("read failed")
+
+		err := Text().Render(&w, io.MultiReader(strings.NewReader("start"), iotest.ErrReader(readErr)))
+
+		assert.ErrorIs(t, err, readErr)
+	})
+
+	t.Run("CommentPrefixesEveryLine", func(t *testing.T) {
+		var w bytes.Buffer
+
+		err := Text().Render(&w, "first\n\nthird", Format(Comment("#")))
+
+		assert.Nil(t, err)
+		assert.Equals(t, w.String(), "# first\n#\n# third")
+	})
+
+	t.Run("NormalizesLineEndings", func(t *testing.T) {
+		var w bytes.Buffer
+
+		err := Text().Render(&w, "a\r\nb\nc", Format(LineEnding("\n")))
+
+		assert.Nil(t, err)
+		assert.Equals(t, w.String(), "a\nb\nc")
+	})
+
+	t.Run("WrapsLinesAtWidth", func(t *testing.T) {
+		var w bytes.Buffer
+		data := "the quick brown fox jumps over the lazy dog\nshort"
+
+		err := Text().Render(&w, data, Wrap(15), Format(Comment("#")))
+
+		assert.Nil(t, err)
+		assert.Equals(t, w.String(), "# the quick brown\n# fox jumps over\n# the lazy dog\n# short")
+	})
+
+	t.Run("WrapKeepsLongWordsWhole", func(t *testing.T) {
+		var w bytes.Buffer
+
+		err := Text().Render(&w, "  see https://example.com/a/very/long/path now", Wrap(10))
+
+		assert.Nil(t, err)
+		assert.Equals(t, w.String(), "  see\nhttps://example.com/a/very/long/path\nnow")
+	})
+
+	t.Run("WrapsStreamedReaders", func(t *testing.T) {
+		var w bytes.Buffer
+
+		err := Text().Render(&w, strings.NewReader("één twee drie\nvier"), Wrap(8), UseCRLF())
+
+		assert.Nil(t, err)
+		assert.Equals(t, w.String(), "één twee\r\ndrie\r\nvier")
+	})
+}
+
+// cancelingReader cancels its context after the first read.
+type cancelingReader struct {
+	io.Reader
+	cancel context.CancelFunc
+}
+
+func (r *cancelingReader) Read(p []byte) (int, error) {
+	defer r.cancel()
+	return r.Reader.Read(p[:10])
 }
@@ -56,22 +56,39 @@ func (t *HTMLTemplate) Render(w io.Writer, data any, opts ...func(*render.Option
 // RenderContext executes the template with context and data.
 // It sets the appropriate content type (text/html) and supports
 // all render options. The context allows for cancellation and timeout control.
+// The prefix and line ending format options are applied to every line, and
+// the output is transcoded when the content type declares a charset other
+// than UTF-8.
 func (t *HTMLTemplate) RenderContext(ctx context.Context, w io.Writer, data any, opts ...func(*render.Options)) error {
 	if err := render.CheckContext(ctx); err != nil {
 		return err
 	}
 	options := render.NewOptions().
 		Use(render.MimeTextHTML()).
+		Use(render.ContextDefaults(ctx)).
 		Use(opts...)
 
+	if err := options.Err(); err != nil {
+		return err
+	}
+
 	tpl, err := t.Clone()
 	if err != nil {
 		return err
 	}
+	tw, err := render.OutputWriter(w, options)
+	if err != nil {
+		return err
+	}
 	if options.Name() == "" {
-		return tpl.Execute(w, data)
+		err = tpl.Execute(tw, data)
+	} else {
+		err = tpl.ExecuteTemplate(tw, options.Name(), data)
+	}
+	if err != nil {
+		return err
 	}
-	return tpl.ExecuteTemplate(w, options.Name(), data)
+	return tw.Close()
 }
 
 // LoadHTML configures a template loader and loads all templates.
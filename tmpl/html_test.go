@@ -189,4 +189,73 @@ func TestHTMLemplate(t *testing.T) {
 
 		assert.NotNil(t, err)
 	})
+	t.Run("ObservedByMetrics", func(t *testing.T) {
+		tpl := HTML("test")
+		htmlTpl := tpl.(*HTMLTemplate)
+
+		_, err := htmlTpl.Parse(`<h1>{{ . }}</h1>`)
+		assert.Nil(t, err)
+
+		metrics := render.NewMetrics()
+		observed := render.Chain(tpl, render.Observe(metrics))
+
+		var w bytes.Buffer
+		err = observed.Render(&w, "Hello")
+
+		assert.Nil(t, err)
+
+		stats := metrics.Snapshot()["tmpl.HTMLTemplate"]
+		assert.Equals(t, stats.Count, int64(1))
+		assert.Equals(t, stats.Bytes, int64(w.Len()))
+	})
+	t.Run("UsesContextDefaults", func(t *testing.T) {
+		tpl := HTML("test")
+		htmlTpl := tpl.(*HTMLTemplate)
+
+		_, err := htmlTpl.Parse(`{{ define "a" }}A{{ . }}{{ end }}{{ define "b" }}B{{ . }}{{ end }}`)
+		assert.Nil(t, err)
+
+		ctx := render.WithDefaults(context.Background(), render.Name("b"))
+
+		var w bytes.Buffer
+		err = tpl.RenderContext(ctx, &w, "1")
+		assert.Nil(t, err)
+		assert.Equals(t, w.String(), "B1")
+
+		w.Reset()
+		err = tpl.RenderContext(ctx, &w, "2", render.Name("a"))
+		assert.Nil(t, err)
+		assert.Equals(t, w.String(), "A2")
+	})
+
+	t.Run("TranscodesToDeclaredCharset", func(t *testing.T) {
+		tpl := HTML("test")
+		htmlTpl := tpl.(*HTMLTemplate)
+
+		_, err := htmlTpl.Parse(`<p>{{ . }}</p>`)
+		assert.Nil(t, err)
+
+		var w bytes.Buffer
+		err = tpl.Render(&w, "Café ☕",
+			render.Mime("text/html", "iso-8859-1"),
+			render.OnUnrepresentable(render.EncodeNCR),
+		)
+
+		assert.Nil(t, err)
+		assert.Equals(t, w.String(), "<p>Caf\xe9 &#9749;</p>")
+	})
+
+	t.Run("AppliesPrefixAndLineEnding", func(t *testing.T) {
+		tpl := HTML("test")
+		htmlTpl := tpl.(*HTMLTemplate)
+
+		_, err := htmlTpl.Parse("<p>\n{{ . }}\n</p>\n")
+		assert.Nil(t, err)
+
+		var w bytes.Buffer
+		err = tpl.Render(&w, "body", render.Format(render.Prefix("  "), render.LineEnding("\r\n")))
+
+		assert.Nil(t, err)
+		assert.Equals(t, w.String(), "  <p>\r\n  body\r\n  </p>\r\n")
+	})
 }
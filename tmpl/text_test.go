@@ -187,4 +187,37 @@ func TestTextTemplate(t *testing.T) {
 
 		assert.NotNil(t, err)
 	})
+	t.Run("UsesContextDefaults", func(t *testing.T) {
+		tpl := Text("test")
+		textTpl := tpl.(*TextTemplate)
+
+		_, err := textTpl.Parse(`{{ define "a" }}A{{ . }}{{ end }}{{ define "b" }}B{{ . }}{{ end }}`)
+		assert.Nil(t, err)
+
+		ctx := render.WithDefaults(context.Background(), render.Name("b"))
+
+		var w bytes.Buffer
+		err = tpl.RenderContext(ctx, &w, "1")
+		assert.Nil(t, err)
+		assert.Equals(t, w.String(), "B1")
+
+		w.Reset()
+		err = tpl.RenderContext(ctx, &w, "2", render.Name("a"))
+		assert.Nil(t, err)
+		assert.Equals(t, w.String(), "A2")
+	})
+
+	t.Run("AppliesPrefixAndLineEnding", func(t *testing.T) {
+		tpl := Text("test")
+		textTpl := tpl.(*TextTemplate)
+
+		_, err := textTpl.Parse("<p>\n{{ . }}\n</p>\n")
+		assert.Nil(t, err)
+
+		var w bytes.Buffer
+		err = tpl.Render(&w, "body", render.Format(render.Prefix("  "), render.LineEnding("\r\n")))
+
+		assert.Nil(t, err)
+		assert.Equals(t, w.String(), "  <p>\r\n  body\r\n  </p>\r\n")
+	})
 }
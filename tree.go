@@ -0,0 +1,401 @@
+// Copyright 2025 The Nanoninja Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+package render
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// TreeDepthKey holds the maximum depth rendered by the Tree renderer,
+// overriding its configuration. Zero renders all levels.
+var TreeDepthKey = NewKey("tree.depth", 0)
+
+// TreeDepth returns an option function limiting the depth rendered by the
+// Tree renderer. Nodes with hidden children are followed by an ellipsis.
+//
+// Example:
+//
+//	render.Tree().Render(os.Stdout, dependencies, render.TreeDepth(2))
+func TreeDepth(depth int) func(*Options) {
+	return Set(TreeDepthKey, depth)
+}
+
+// Node is implemented by values rendered as a node of a tree, such as
+// the packages of a dependency graph. Children may be Nodes themselves,
+// or any value walked by the Tree renderer.
+type Node interface {
+	// Label returns the text displayed for the node.
+	Label() string
+
+	// Children returns the children of the node, in display order.
+	Children() []any
+}
+
+// TreeConfig defines configuration options for tree rendering.
+type TreeConfig struct {
+	// Root is the label of the first line, under which the data is
+	// displayed. When empty, the top level entries are displayed first.
+	// It is ignored when the data is a Node, whose label is used.
+	Root string
+
+	// ASCII uses ASCII connectors, such as "|--", in place of the
+	// Unicode box drawing characters, for terminals or logs that cannot
+	// display them.
+	ASCII bool
+
+	// MaxDepth limits the number of levels displayed. Zero displays all
+	// levels.
+	MaxDepth int
+}
+
+// treeConnectors holds the strings drawing the branches of a tree.
+type treeConnectors struct {
+	branch   string // Connector of an entry followed by siblings
+	last     string // Connector of the last entry of a level
+	vertical string // Indentation below an entry followed by siblings
+	space    string // Indentation below the last entry of a level
+	more     string // Marker of children hidden by the depth limit
+}
+
+var (
+	unicodeConnectors = treeConnectors{"├── ", "└── ", "│   ", "    ", "…"}
+	asciiConnectors   = treeConnectors{"|-- ", "`-- ", "|   ", "    ", "..."}
+)
+
+// treeRenderer implements the rendering of hierarchical data as a tree.
+type treeRenderer struct {
+	config TreeConfig
+}
+
+// Tree creates a new tree renderer with Unicode connectors and no depth
+// limit. This is the recommended constructor for most use cases.
+func Tree() Renderer {
+	return NewTree(TreeConfig{})
+}
+
+// NewTree creates a tree renderer with custom configuration.
+func NewTree(c TreeConfig) Renderer {
+	return &treeRenderer{config: c}
+}
+
+// Render writes data as a tree using a background context.
+// It walks the following values:
+// - Node: its label, then its children
+// - maps: one entry per key, sorted for deterministic output
+// - slices and arrays: one entry per element
+// - structs: one entry per exported field, in declaration order, named
+// by the "tree" struct tag when set, and skipped when the tag is "-"
+// - pointers and interfaces: the value they point to
+// Other values, including fmt.Stringer types, are displayed as leaves.
+// Scalar entries are displayed as "key: value" on a single line.
+func (r *treeRenderer) Render(w io.Writer, data any, opts ...func(*Options)) error {
+	return r.RenderContext(context.Background(), w, data, opts...)
+}
+
+// RenderContext writes data as a tree with context support.
+// Pointers, maps and slices already displayed by an ancestor are
+// displayed as "<cycle>" instead of being walked again, so that cyclic
+// graphs terminate. The depth limit is set by the TreeDepth option, or
+// else by the configuration.
+// The content type is text/plain by default and every line ends with a
+// line break, honoring the prefix and line ending format options.
+func (r *treeRenderer) RenderContext(ctx context.Context, w io.Writer, data any, opts ...func(*Options)) error {
+	if err := CheckContext(ctx); err != nil {
+		return err
+	}
+	options := NewOptions().
+		Use(MimeTextPlain()).
+		Use(ContextDefaults(ctx)).
+		Use(opts...)
+
+	if err := options.Err(); err != nil {
+		return err
+	}
+
+	t := &treeWriter{
+		ctx:       ctx,
+		maxDepth:  r.config.MaxDepth,
+		connector: unicodeConnectors,
+		visiting:  make(map[valueRef]bool),
+	}
+	if r.config.ASCII {
+		t.connector = asciiConnectors
+	}
+	if depth, ok := TreeDepthKey.Lookup(options); ok {
+		t.maxDepth = depth
+	}
+
+	root := treeEntry{label: r.config.Root}
+	if node, ok := data.(Node); ok {
+		root = treeEntry{label: node.Label(), value: reflect.ValueOf(node)}
+	} else {
+		root.value = reflect.ValueOf(data)
+	}
+	if err := t.root(root); err != nil {
+		return err
+	}
+
+	out, err := OutputWriter(w, options)
+	if err != nil {
+		return err
+	}
+	if _, err := io.WriteString(out, t.b.String()); err != nil {
+		return err
+	}
+	return out.Close()
+}
+
+// treeEntry is a line of the tree: a label, and the value whose children
+// are displayed below it.
+type treeEntry struct {
+	label string
+	value reflect.Value // Invalid for leaves
+}
+
+// treeWriter builds the lines of a tree.
+type treeWriter struct {
+	ctx       context.Context
+	maxDepth  int
+	connector treeConnectors
+	visiting  map[valueRef]bool // References displayed by the current path
+	b         strings.Builder
+}
+
+// root writes the root entry, followed by its children.
+func (t *treeWriter) root(e treeEntry) error {
+	if e.label != "" {
+		t.line("", "", "", e.label)
+		return t.children(e.value, "", 1)
+	}
+	return t.children(e.value, "", 0)
+}
+
+// children writes the entries of v at the given depth, each line starting
+// with indent.
+func (t *treeWriter) children(v reflect.Value, indent string, depth int) error {
+	if err := CheckContext(t.ctx); err != nil {
+		return err
+	}
+	ref, isRef := valueReference(v)
+	if isRef {
+		t.visiting[ref] = true
+		defer delete(t.visiting, ref)
+	}
+	entries := t.entries(v)
+	if len(entries) == 0 {
+		return nil
+	}
+	if t.maxDepth > 0 && depth >= t.maxDepth {
+		t.line(indent, t.connector.last, t.connector.space, t.connector.more)
+		return nil
+	}
+	for i, e := range entries {
+		connector, below := t.connector.branch, t.connector.vertical
+		if i == len(entries)-1 {
+			connector, below = t.connector.last, t.connector.space
+		}
+		if ref, ok := valueReference(e.value); ok && t.visiting[ref] {
+			t.line(indent, connector, below, e.label+" <cycle>")
+			continue
+		}
+		t.line(indent, connector, below, e.label)
+		if err := t.children(e.value, indent+below, depth+1); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// line writes a label with its connector. Lines of a multi-line label
+// are aligned below the first one, after the indentation of its children.
+func (t *treeWriter) line(indent, connector, below, label string) {
+	for i, text := range strings.Split(label, "\n") {
+		if i == 0 {
+			t.b.WriteString(indent + connector)
+		} else {
+			t.b.WriteString(indent + below)
+		}
+		t.b.WriteString(strings.TrimRight(text, "\r"))
+		t.b.WriteByte('\n')
+	}
+}
+
+// entries returns the entries displayed below v.
+func (t *treeWriter) entries(v reflect.Value) []treeEntry {
+	v = treeIndirect(v)
+	if !v.IsValid() {
+		return nil
+	}
+	if node, ok := treeNode(v); ok {
+		children := node.Children()
+		entries := make([]treeEntry, 0, len(children))
+		for _, child := range children {
+			entries = append(entries, t.entry("", reflect.ValueOf(child)))
+		}
+		return entries
+	}
+	if isTreeLeaf(v) {
+		return nil
+	}
+
+	var entries []treeEntry
+	switch v.Kind() {
+	case reflect.Map:
+		for _, key := range sortedMapKeys(v) {
+			entries = append(entries, t.entry(fmt.Sprint(key.Interface()), v.MapIndex(key)))
+		}
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			entries = append(entries, t.entry("", v.Index(i)))
+		}
+	case reflect.Struct:
+		typ := v.Type()
+		for i := 0; i < typ.NumField(); i++ {
+			field := typ.Field(i)
+			if !field.IsExported() {
+				continue
+			}
+			name := field.Name
+			if tag := field.Tag.Get("tree"); tag == "-" {
+				continue
+			} else if tag != "" {
+				name = tag
+			}
+			entries = append(entries, t.entry(name, v.Field(i)))
+		}
+	}
+	return entries
+}
+
+// entry builds the entry displaying v under the given name. Leaves are
+// displayed inline as "name: value", and other values as their name or,
+// for elements of slices, as their index.
+func (t *treeWriter) entry(name string, v reflect.Value) treeEntry {
+	iv := treeIndirect(v)
+	if node, ok := treeNode(iv); ok {
+		label := node.Label()
+		if name != "" {
+			label = name + ": " + label
+		}
+		return treeEntry{label: label, value: v}
+	}
+	if !iv.IsValid() || isTreeLeaf(iv) || isTreeEmpty(iv) {
+		value := treeScalar(iv)
+		if name == "" {
+			return treeEntry{label: value}
+		}
+		return treeEntry{label: name + ": " + value}
+	}
+	if name == "" {
+		name = iv.Type().String()
+	}
+	return treeEntry{label: name, value: v}
+}
+
+// treeIndirect follows the pointers and interfaces of v, stopping at
+// values implementing Node or displayed as leaves, such as fmt.Stringer
+// types with pointer receivers.
+func treeIndirect(v reflect.Value) reflect.Value {
+	for v.IsValid() && (v.Kind() == reflect.Pointer || v.Kind() == reflect.Interface) {
+		if v.IsNil() {
+			return reflect.Value{}
+		}
+		if _, ok := treeNode(v); ok || implementsLeaf(v) {
+			return v
+		}
+		v = v.Elem()
+	}
+	return v
+}
+
+// treeNode returns v as a Node, if it implements it.
+func treeNode(v reflect.Value) (Node, bool) {
+	if !v.IsValid() || !v.CanInterface() {
+		return nil, false
+	}
+	node, ok := v.Interface().(Node)
+	return node, ok
+}
+
+// implementsLeaf reports whether v implements an interface displaying
+// it as a single value.
+func implementsLeaf(v reflect.Value) bool {
+	if !v.CanInterface() {
+		return false
+	}
+	switch v.Interface().(type) {
+	case fmt.Stringer, error, []byte:
+		return true
+	}
+	return false
+}
+
+// isTreeLeaf reports whether v is displayed as a single value.
+func isTreeLeaf(v reflect.Value) bool {
+	if implementsLeaf(v) {
+		return true
+	}
+	switch v.Kind() {
+	case reflect.Map, reflect.Slice, reflect.Array, reflect.Struct:
+		return false
+	}
+	return true
+}
+
+// isTreeEmpty reports whether v is a collection without entries.
+func isTreeEmpty(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Map, reflect.Slice, reflect.Array:
+		return v.Len() == 0
+	}
+	return false
+}
+
+// treeScalar returns the text displaying a leaf value.
+func treeScalar(v reflect.Value) string {
+	if !v.IsValid() {
+		return "<nil>"
+	}
+	switch v.Kind() {
+	case reflect.Map:
+		if v.Len() == 0 && !isTreeLeaf(v) {
+			return "{}"
+		}
+	case reflect.Slice, reflect.Array:
+		if v.Len() == 0 && !isTreeLeaf(v) {
+			return "[]"
+		}
+	}
+	if v.CanInterface() {
+		if b, ok := v.Interface().([]byte); ok {
+			return string(b)
+		}
+		return fmt.Sprint(v.Interface())
+	}
+	return fmt.Sprint(v)
+}
+
+// sortedMapKeys returns the keys of a map, sorted numerically for
+// numbers and by their text otherwise.
+func sortedMapKeys(v reflect.Value) []reflect.Value {
+	keys := v.MapKeys()
+	sort.Slice(keys, func(i, j int) bool {
+		a, b := keys[i], keys[j]
+		switch a.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			return a.Int() < b.Int()
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+			return a.Uint() < b.Uint()
+		case reflect.Float32, reflect.Float64:
+			return a.Float() < b.Float()
+		}
+		return fmt.Sprint(a.Interface()) < fmt.Sprint(b.Interface())
+	})
+	return keys
+}
@@ -0,0 +1,211 @@
+// Copyright 2025 The Nanoninja Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+package render
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/nanoninja/assert"
+)
+
+var (
+	_ Renderer = (*treeRenderer)(nil)
+	_ Renderer = Tree()
+)
+
+// treePackage is a Node of a dependency graph.
+type treePackage struct {
+	name string
+	deps []*treePackage
+}
+
+func (p *treePackage) Label() string { return p.name }
+
+func (p *treePackage) Children() []any {
+	children := make([]any, len(p.deps))
+	for i, dep := range p.deps {
+		children[i] = dep
+	}
+	return children
+}
+
+// treeServer is a struct walked by the Tree renderer.
+type treeServer struct {
+	Host    string
+	Port    int
+	Tags    []string `tree:"tags"`
+	Secret  string   `tree:"-"`
+	Timeout time.Duration
+	private string
+}
+
+// treeLink is a pointer graph with cycles.
+type treeLink struct {
+	Name string
+	Next *treeLink
+}
+
+func TestTreeRenderer(t *testing.T) {
+	t.Run("RendersSortedMaps", func(t *testing.T) {
+		var w bytes.Buffer
+		data := map[string]any{
+			"server": map[string]any{"port": 8080, "host": "localhost"},
+			"debug":  true,
+			"users":  []string{"ana", "bob"},
+		}
+
+		err := Tree().Render(&w, data)
+
+		assert.Nil(t, err)
+		assert.Equals(t, w.String(), ""+
+			"├── debug: true\n"+
+			"├── server\n"+
+			"│   ├── host: localhost\n"+
+			"│   └── port: 8080\n"+
+			"└── users\n"+
+			"    ├── ana\n"+
+			"    └── bob\n")
+	})
+
+	t.Run("SortsNumericKeysNumerically", func(t *testing.T) {
+		var w bytes.Buffer
+
+		err := Tree().Render(&w, map[int]string{10: "ten", 2: "two", 1: "one"})
+
+		assert.Nil(t, err)
+		assert.Equals(t, w.String(), "├── 1: one\n├── 2: two\n└── 10: ten\n")
+	})
+
+	t.Run("RendersStructFields", func(t *testing.T) {
+		var w bytes.Buffer
+		data := &treeServer{Host: "db", Port: 5432, Tags: []string{"primary"}, Secret: "x", Timeout: time.Second}
+
+		err := NewTree(TreeConfig{Root: "config", ASCII: true}).Render(&w, data)
+
+		assert.Nil(t, err)
+		assert.Equals(t, w.String(), ""+
+			"config\n"+
+			"|-- Host: db\n"+
+			"|-- Port: 5432\n"+
+			"|-- tags\n"+
+			"|   `-- primary\n"+
+			"`-- Timeout: 1s\n")
+	})
+
+	t.Run("RendersNodes", func(t *testing.T) {
+		var w bytes.Buffer
+		text := &treePackage{name: "text"}
+		data := &treePackage{name: "app", deps: []*treePackage{
+			{name: "http", deps: []*treePackage{text}},
+			text,
+		}}
+
+		err := Tree().Render(&w, data)
+
+		assert.Nil(t, err)
+		assert.Equals(t, w.String(), ""+
+			"app\n"+
+			"├── http\n"+
+			"│   └── text\n"+
+			"└── text\n")
+	})
+
+	t.Run("DetectsNodeCycles", func(t *testing.T) {
+		var w bytes.Buffer
+		a := &treePackage{name: "a"}
+		b := &treePackage{name: "b", deps: []*treePackage{a}}
+		a.deps = []*treePackage{b}
+
+		err := Tree().Render(&w, a)
+
+		assert.Nil(t, err)
+		assert.Equals(t, w.String(), "a\n└── b\n    └── a <cycle>\n")
+	})
+
+	t.Run("DetectsPointerCycles", func(t *testing.T) {
+		var w bytes.Buffer
+		first := &treeLink{Name: "first"}
+		first.Next = &treeLink{Name: "second", Next: first}
+
+		err := Tree().Render(&w, first)
+
+		assert.Nil(t, err)
+		assert.Equals(t, w.String(), ""+
+			"├── Name: first\n"+
+			"└── Next\n"+
+			"    ├── Name: second\n"+
+			"    └── Next <cycle>\n")
+	})
+
+	t.Run("DetectsMapCycles", func(t *testing.T) {
+		var w bytes.Buffer
+		data := map[string]any{"name": "loop"}
+		data["self"] = data
+
+		err := Tree().Render(&w, data)
+
+		assert.Nil(t, err)
+		assert.Equals(t, w.String(), "├── name: loop\n└── self <cycle>\n")
+	})
+
+	t.Run("LimitsDepth", func(t *testing.T) {
+		var w bytes.Buffer
+		data := map[string]any{"a": map[string]any{"b": map[string]any{"c": 1}}, "d": 2}
+
+		err := NewTree(TreeConfig{MaxDepth: 3}).Render(&w, data, TreeDepth(2))
+
+		assert.Nil(t, err)
+		assert.Equals(t, w.String(), ""+
+			"├── a\n"+
+			"│   └── b\n"+
+			"│       └── …\n"+
+			"└── d: 2\n")
+	})
+
+	t.Run("RendersEmptyAndNilValues", func(t *testing.T) {
+		var w bytes.Buffer
+		data := map[string]any{"list": []int{}, "map": map[string]int{}, "none": nil, "ptr": (*treeLink)(nil)}
+
+		err := Tree().Render(&w, data)
+
+		assert.Nil(t, err)
+		assert.Equals(t, w.String(), ""+
+			"├── list: []\n"+
+			"├── map: {}\n"+
+			"├── none: <nil>\n"+
+			"└── ptr: <nil>\n")
+	})
+
+	t.Run("AlignsMultilineLabels", func(t *testing.T) {
+		var w bytes.Buffer
+
+		err := Tree().Render(&w, []string{"first\nline", "second"})
+
+		assert.Nil(t, err)
+		assert.Equals(t, w.String(), "├── first\n│   line\n└── second\n")
+	})
+
+	t.Run("AppliesPrefixAndLineEnding", func(t *testing.T) {
+		var w bytes.Buffer
+
+		err := NewTree(TreeConfig{ASCII: true}).Render(&w, []int{1, 2}, Format(Comment("#"), LineEnding("\r\n")))
+
+		assert.Nil(t, err)
+		assert.Equals(t, w.String(), "# |-- 1\r\n# `-- 2\r\n")
+	})
+
+	t.Run("RespectsContextCancellation", func(t *testing.T) {
+		var w bytes.Buffer
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		err := Tree().RenderContext(ctx, &w, []int{1})
+
+		assert.ErrorIs(t, err, context.Canceled)
+		assert.Equals(t, w.Len(), 0)
+	})
+}
@@ -0,0 +1,288 @@
+// Copyright 2025 The Nanoninja Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+package render
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Supported vCard versions.
+const (
+	// VCard4 is vCard 4.0, as defined by RFC 6350.
+	VCard4 = "4.0"
+
+	// VCard3 is vCard 3.0, as defined by RFC 2426, for older address books.
+	VCard3 = "3.0"
+)
+
+// Contact is a person or an organization, written as a vCard.
+//
+// Example:
+//
+//	render.VCard().Render(w, render.Contact{
+//	    Name:   render.ContactName{Given: "Ada", Family: "Lovelace"},
+//	    Emails: []render.ContactValue{{Value: "ada@example.com", Types: []string{"work"}, Preferred: true}},
+//	    Phones: []render.ContactValue{{Value: "+44 20 7946 0018", Types: []string{"cell"}}},
+//	})
+type Contact struct {
+	// UID uniquely and persistently identifies the contact.
+	UID string
+
+	// FormattedName is the display name of the contact, written as FN.
+	// The default is built from the parts of Name.
+	FormattedName string
+
+	// Name holds the parts of the name of the contact, written as N.
+	Name ContactName
+
+	// Nickname is the nickname of the contact.
+	Nickname string
+
+	// Organization is the organization of the contact, written as ORG.
+	Organization string
+
+	// Title is the job title of the contact.
+	Title string
+
+	// Emails are the email addresses of the contact.
+	Emails []ContactValue
+
+	// Phones are the telephone numbers of the contact, written as TEL.
+	Phones []ContactValue
+
+	// Addresses are the postal addresses of the contact.
+	Addresses []ContactAddress
+
+	// PhotoURL is the URI of a photo of the contact.
+	PhotoURL string
+
+	// URL links to a page about the contact.
+	URL string
+
+	// Note is a free text note about the contact.
+	Note string
+}
+
+// ContactName holds the parts of the name of a contact.
+type ContactName struct {
+	Family     string
+	Given      string
+	Additional string
+	Prefix     string
+	Suffix     string
+}
+
+// String returns the parts of the name that are set, separated by spaces,
+// such as "Dr. Ada Lovelace".
+func (n ContactName) String() string {
+	var parts []string
+	for _, part := range []string{n.Prefix, n.Given, n.Additional, n.Family, n.Suffix} {
+		if part != "" {
+			parts = append(parts, part)
+		}
+	}
+	return strings.Join(parts, " ")
+}
+
+// ContactValue is an email address or a telephone number of a contact.
+type ContactValue struct {
+	// Value is the address or number.
+	Value string
+
+	// Types describe the value, such as "work", "home", "cell" or "voice".
+	Types []string
+
+	// Preferred marks the value preferred over the others of its kind.
+	Preferred bool
+}
+
+// ContactAddress is a postal address of a contact, written as ADR.
+type ContactAddress struct {
+	// Types describe the address, such as "work" or "home".
+	Types []string
+
+	POBox      string
+	Extended   string
+	Street     string
+	Locality   string
+	Region     string
+	PostalCode string
+	Country    string
+}
+
+// VCardConfig defines configuration options for vCard rendering.
+type VCardConfig struct {
+	// Version is the vCard version, VCard4 or VCard3. The default is VCard4.
+	Version string
+}
+
+// vcardRenderer implements the rendering of contacts as vCards.
+type vcardRenderer struct {
+	config VCardConfig
+}
+
+// VCard creates a new renderer writing contacts as vCard 4.0.
+// This is the recommended constructor for most use cases.
+func VCard() Renderer {
+	return NewVCard(VCardConfig{})
+}
+
+// NewVCard creates a vCard renderer with custom configuration, such as
+// vCard 3.0 for older address books.
+func NewVCard(c VCardConfig) Renderer {
+	if c.Version == "" {
+		c.Version = VCard4
+	}
+	return &vcardRenderer{config: c}
+}
+
+// Render writes data as vCards using a background context.
+// Data must be a Contact, or a []Contact written as one card per contact.
+func (r *vcardRenderer) Render(w io.Writer, data any, opts ...func(*Options)) error {
+	return r.RenderContext(context.Background(), w, data, opts...)
+}
+
+// RenderContext writes data as vCards with context support.
+// Text values are escaped, and content lines longer than 75 octets are
+// folded without splitting UTF-8 characters. Lines always end with CRLF,
+// and the format options are ignored.
+// Contacts without formatted name or name return an error wrapping
+// ErrInvalidData before anything is written, and unsupported versions an
+// error wrapping ErrInvalidParam.
+// The content type is text/vcard.
+func (r *vcardRenderer) RenderContext(ctx context.Context, w io.Writer, data any, opts ...func(*Options)) error {
+	if err := CheckContext(ctx); err != nil {
+		return err
+	}
+	if r.config.Version != VCard4 && r.config.Version != VCard3 {
+		return fmt.Errorf("%w: vCard version %q", ErrInvalidParam, r.config.Version)
+	}
+	var contacts []Contact
+	switch v := data.(type) {
+	case Contact:
+		contacts = []Contact{v}
+	case *Contact:
+		if v == nil {
+			return ErrInvalidData
+		}
+		contacts = []Contact{*v}
+	case []Contact:
+		contacts = v
+	default:
+		return ErrInvalidData
+	}
+
+	options := NewOptions().
+		Use(MimeVCard()).
+		Use(ContextDefaults(ctx)).
+		Use(opts...)
+
+	if err := options.Err(); err != nil {
+		return err
+	}
+
+	var b strings.Builder
+	for i := range contacts {
+		if err := CheckContext(ctx); err != nil {
+			return err
+		}
+		if err := r.card(&b, &contacts[i]); err != nil {
+			return err
+		}
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// card writes the vCard of c.
+func (r *vcardRenderer) card(b *strings.Builder, c *Contact) error {
+	name := c.FormattedName
+	if name == "" {
+		name = c.Name.String()
+	}
+	if name == "" {
+		return fmt.Errorf("%w: contact without name", ErrInvalidData)
+	}
+	v3 := r.config.Version == VCard3
+
+	writeContentLine(b, "BEGIN:VCARD")
+	writeContentLine(b, "VERSION:"+r.config.Version)
+	writeContentLine(b, "FN:"+icalText(name))
+	if v3 || c.Name != (ContactName{}) {
+		n := c.Name
+		writeContentLine(b, "N:"+vcardStructured(n.Family, n.Given, n.Additional, n.Prefix, n.Suffix))
+	}
+	vcardOptional(b, "NICKNAME", c.Nickname)
+	vcardOptional(b, "ORG", c.Organization)
+	vcardOptional(b, "TITLE", c.Title)
+	for _, email := range c.Emails {
+		writeContentLine(b, "EMAIL"+vcardTypes(email.Types, email.Preferred, v3)+":"+icalText(email.Value))
+	}
+	for _, phone := range c.Phones {
+		writeContentLine(b, "TEL"+vcardTypes(phone.Types, phone.Preferred, v3)+":"+icalText(phone.Value))
+	}
+	for _, a := range c.Addresses {
+		writeContentLine(b, "ADR"+vcardTypes(a.Types, false, v3)+":"+
+			vcardStructured(a.POBox, a.Extended, a.Street, a.Locality, a.Region, a.PostalCode, a.Country))
+	}
+	if c.PhotoURL != "" {
+		if err := validateICalValue("PHOTO", c.PhotoURL); err != nil {
+			return err
+		}
+		if v3 {
+			writeContentLine(b, "PHOTO;VALUE=uri:"+c.PhotoURL)
+		} else {
+			writeContentLine(b, "PHOTO:"+c.PhotoURL)
+		}
+	}
+	if c.URL != "" {
+		if err := validateICalValue("URL", c.URL); err != nil {
+			return err
+		}
+		writeContentLine(b, "URL:"+c.URL)
+	}
+	vcardOptional(b, "NOTE", c.Note)
+	vcardOptional(b, "UID", c.UID)
+	writeContentLine(b, "END:VCARD")
+	return nil
+}
+
+// vcardOptional writes a text property when its value is not empty.
+func vcardOptional(b *strings.Builder, name, value string) {
+	if value != "" {
+		writeContentLine(b, name+":"+icalText(value))
+	}
+}
+
+// vcardStructured joins the escaped components of a structured value,
+// such as N or ADR, with semicolons.
+func vcardStructured(components ...string) string {
+	for i, component := range components {
+		components[i] = icalText(component)
+	}
+	return strings.Join(components, ";")
+}
+
+// vcardTypes formats the TYPE parameter of a property. Preferred values
+// use PREF=1 in vCard 4.0 and the PREF type in vCard 3.0.
+func vcardTypes(types []string, preferred, v3 bool) string {
+	values := make([]string, 0, len(types)+1)
+	for _, t := range types {
+		values = append(values, icalParam(t))
+	}
+	if preferred && v3 {
+		values = append(values, "PREF")
+	}
+	var s string
+	if len(values) > 0 {
+		s = ";TYPE=" + strings.Join(values, ",")
+	}
+	if preferred && !v3 {
+		s += ";PREF=1"
+	}
+	return s
+}
@@ -0,0 +1,156 @@
+// Copyright 2025 The Nanoninja Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+package render
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/nanoninja/assert"
+)
+
+var (
+	_ Renderer = (*vcardRenderer)(nil)
+	_ Renderer = VCard()
+)
+
+// newVCardContact returns a contact using every property.
+func newVCardContact() Contact {
+	return Contact{
+		UID:          "urn:uuid:4fbe8971-0bc3-424c-9c26-36c3e1eff6b1",
+		Name:         ContactName{Family: "Lovelace", Given: "Ada", Prefix: "Countess"},
+		Nickname:     "Ada",
+		Organization: "Analytical Engines; Ltd",
+		Title:        "Mathematician",
+		Emails: []ContactValue{
+			{Value: "ada@example.com", Types: []string{"work"}, Preferred: true},
+			{Value: "ada@home.example"},
+		},
+		Phones: []ContactValue{{Value: "+44 20 7946 0018", Types: []string{"cell", "voice"}}},
+		Addresses: []ContactAddress{{
+			Types:      []string{"home"},
+			Street:     "12 St James's Square",
+			Locality:   "London",
+			PostalCode: "SW1Y 4JH",
+			Country:    "United Kingdom",
+		}},
+		PhotoURL: "https://example.com/ada.jpg",
+		Note:     "First programmer,\nborn 1815",
+	}
+}
+
+func TestVCardRenderer(t *testing.T) {
+	t.Run("RendersVersion4", func(t *testing.T) {
+		var (
+			w       bytes.Buffer
+			options *Options
+		)
+
+		err := VCard().Render(&w, newVCardContact(), CaptureOptions(&options))
+
+		assert.Nil(t, err)
+		assert.Equals(t, options.ContentType(), "text/vcard; charset=utf-8")
+		assert.Equals(t, w.String(), "BEGIN:VCARD\r\n"+
+			"VERSION:4.0\r\n"+
+			"FN:Countess Ada Lovelace\r\n"+
+			"N:Lovelace;Ada;;Countess;\r\n"+
+			"NICKNAME:Ada\r\n"+
+			"ORG:Analytical Engines\\; Ltd\r\n"+
+			"TITLE:Mathematician\r\n"+
+			"EMAIL;TYPE=work;PREF=1:ada@example.com\r\n"+
+			"EMAIL:ada@home.example\r\n"+
+			"TEL;TYPE=cell,voice:+44 20 7946 0018\r\n"+
+			"ADR;TYPE=home:;;12 St James's Square;London;;SW1Y 4JH;United Kingdom\r\n"+
+			"PHOTO:https://example.com/ada.jpg\r\n"+
+			"NOTE:First programmer\\,\\nborn 1815\r\n"+
+			"UID:urn:uuid:4fbe8971-0bc3-424c-9c26-36c3e1eff6b1\r\n"+
+			"END:VCARD\r\n")
+	})
+
+	t.Run("RendersVersion3", func(t *testing.T) {
+		var w bytes.Buffer
+
+		err := NewVCard(VCardConfig{Version: VCard3}).Render(&w, newVCardContact())
+
+		assert.Nil(t, err)
+		assert.StringContains(t, w.String(), "\r\nVERSION:3.0\r\n")
+		assert.StringContains(t, w.String(), "\r\nEMAIL;TYPE=work,PREF:ada@example.com\r\n")
+		assert.StringContains(t, w.String(), "\r\nPHOTO;VALUE=uri:https://example.com/ada.jpg\r\n")
+	})
+
+	t.Run("RequiresNameInVersion3", func(t *testing.T) {
+		var w bytes.Buffer
+
+		err := NewVCard(VCardConfig{Version: VCard3}).Render(&w, Contact{FormattedName: "ACME"})
+
+		assert.Nil(t, err)
+		assert.Equals(t, w.String(), "BEGIN:VCARD\r\nVERSION:3.0\r\nFN:ACME\r\nN:;;;;\r\nEND:VCARD\r\n")
+	})
+
+	t.Run("RendersSlicesAsCards", func(t *testing.T) {
+		var w bytes.Buffer
+
+		err := VCard().Render(&w, []Contact{{FormattedName: "A"}, {FormattedName: "B"}})
+
+		assert.Nil(t, err)
+		assert.Equals(t, w.String(), "BEGIN:VCARD\r\nVERSION:4.0\r\nFN:A\r\nEND:VCARD\r\n"+
+			"BEGIN:VCARD\r\nVERSION:4.0\r\nFN:B\r\nEND:VCARD\r\n")
+	})
+
+	t.Run("FoldsLongLines", func(t *testing.T) {
+		var w bytes.Buffer
+		note := strings.Repeat("note ", 40)
+
+		err := VCard().Render(&w, &Contact{FormattedName: "A", Note: note})
+
+		assert.Nil(t, err)
+		for _, line := range strings.Split(w.String(), "\r\n") {
+			assert.True(t, len(line) <= 75)
+		}
+		assert.StringContains(t, strings.ReplaceAll(w.String(), "\r\n ", ""), "\r\nNOTE:"+note+"\r\n")
+	})
+
+	t.Run("RejectsContactsWithoutName", func(t *testing.T) {
+		var w bytes.Buffer
+
+		err := VCard().Render(&w, []Contact{{FormattedName: "A"}, {Emails: []ContactValue{{Value: "a@example.com"}}}})
+
+		assert.ErrorIs(t, err, ErrInvalidData)
+		assert.Equals(t, w.Len(), 0)
+	})
+
+	t.Run("RejectsUnsupportedVersions", func(t *testing.T) {
+		var w bytes.Buffer
+
+		err := NewVCard(VCardConfig{Version: "2.1"}).Render(&w, Contact{FormattedName: "A"})
+
+		assert.ErrorIs(t, err, ErrInvalidParam)
+	})
+
+	t.Run("RejectsInvalidData", func(t *testing.T) {
+		var w bytes.Buffer
+
+		err := VCard().Render(&w, map[string]string{"FN": "A"})
+
+		assert.ErrorIs(t, err, ErrInvalidData)
+	})
+
+	t.Run("ReturnsContextError", func(t *testing.T) {
+		var w bytes.Buffer
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		err := VCard().RenderContext(ctx, &w, Contact{FormattedName: "A"})
+
+		assert.ErrorIs(t, err, context.Canceled)
+		assert.Equals(t, w.Len(), 0)
+	})
+}
+
+func TestContactName(t *testing.T) {
+	assert.Equals(t, ContactName{Given: "Ada", Family: "Lovelace", Suffix: "PhD"}.String(), "Ada Lovelace PhD")
+	assert.Equals(t, ContactName{}.String(), "")
+}
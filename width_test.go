@@ -0,0 +1,41 @@
+// Copyright 2025 The Nanoninja Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+package render
+
+import (
+	"testing"
+
+	"github.com/nanoninja/assert"
+)
+
+func TestDisplayWidth(t *testing.T) {
+	tests := []struct {
+		name string
+		s    string
+		want int
+	}{
+		{"Empty", "", 0},
+		{"ASCII", "hello", 5},
+		{"Latin", "héllo wörld", 11},
+		{"CombiningMarks", "e\u0301a\u0308", 2},
+		{"CJK", "日本語", 6},
+		{"Hangul", "한국어", 6},
+		{"Fullwidth", "ＡＢ", 4},
+		{"MixedWidths", "a日b", 4},
+		{"Emoji", "ok 👍", 5},
+		{"EmojiWithVariationSelector", "\u2764\ufe0f", 1},
+		{"EmojiZWJSequence", "\U0001F468\u200d\U0001F469\u200d\U0001F467", 2},
+		{"ControlCharacters", "a\tb\x00", 2},
+		{"ZeroWidthSpace", "a\u200bb", 2},
+		{"ANSIStyles", "\x1b[1;31mred\x1b[0m", 3},
+		{"ANSIStylesAroundWide", "\x1b[38;5;208m日本\x1b[0m", 4},
+		{"OSCHyperlink", "\x1b]8;;https://example.com\x07link\x1b]8;;\x1b\\", 4},
+		{"TruncatedEscape", "ab\x1b[", 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equals(t, DisplayWidth(tt.s), tt.want)
+		})
+	}
+}
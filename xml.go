@@ -6,7 +6,6 @@ package render
 import (
 	"context"
 	"encoding/xml"
-	"fmt"
 	"io"
 )
 
@@ -15,6 +14,7 @@ import (
 type XMLConfig struct {
 	// Prefix specifies the string to prepend at the start of each line.
 	// This is particularly useful when embedding XML within another format.
+	// It is overridden by the Prefix format option.
 	Prefix string
 
 	// Indent specifies the string used for each level of indentation.
@@ -22,7 +22,8 @@ type XMLConfig struct {
 	Indent string
 
 	// Header controls whether to include the XML declaration at the start.
-	// When true, adds <?xml version="1.0" encoding="UTF-8"?>.
+	// When true, adds <?xml version="1.0" encoding="UTF-8"?>, declaring
+	// the charset of the content type when it is not UTF-8.
 	Header bool
 }
 
@@ -61,34 +62,43 @@ func (r *xmlRenderer) Render(w io.Writer, data any, opts ...func(*Options)) erro
 // RenderContext writes the XML representation of data with context support.
 // It handles:
 // - XML header inclusion based on configuration
-// - Pretty printing with configurable prefix and indentation
+// - Pretty printing with configurable indentation
+// - Prefix and line ending applied to every line, including the header
 // - Content type setting to application/xml
+// - Transcoding to the charset declared by the content type
 func (r *xmlRenderer) RenderContext(ctx context.Context, w io.Writer, data any, opts ...func(*Options)) error {
 	if err := CheckContext(ctx); err != nil {
 		return err
 	}
 	options := NewOptions().
-		Use(MimeXML()).
+		Use(MimeXML(), Format(Prefix(r.config.Prefix))).
+		Use(ContextDefaults(ctx)).
 		Use(opts...)
 
+	if err := options.Err(); err != nil {
+		return err
+	}
+
+	tw, err := OutputWriter(w, options)
+	if err != nil {
+		return err
+	}
 	if r.config.Header {
-		if _, err := fmt.Fprint(w, xml.Header); err != nil {
+		if _, err := io.WriteString(tw, xmlDeclaration(options)); err != nil {
 			return err
 		}
 	}
-	encoder := xml.NewEncoder(w)
+	encoder := xml.NewEncoder(tw)
 
 	if options.format.pretty {
-		prefix := r.config.Prefix
 		indent := r.config.Indent
-
-		if options.format.prefix != "" {
-			prefix = options.format.prefix
-		}
 		if options.format.indent != "" {
 			indent = options.format.indent
 		}
-		encoder.Indent(prefix, indent)
+		encoder.Indent("", indent)
+	}
+	if err := encoder.Encode(data); err != nil {
+		return err
 	}
-	return encoder.Encode(data)
+	return tw.Close()
 }
@@ -22,7 +22,7 @@ const xmlPrettyTest = xml.Header + `<root>
     <message>Pretty test</message>
 </root>`
 
-const xmlPrefixTest = xml.Header + `>><root>
+const xmlPrefixTest = ">>" + xml.Header + `>><root>
 >>  <message>Prefix test</message>
 >></root>`
 